@@ -2,10 +2,55 @@ package bag
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/jordanorelli/generic/iter"
 )
 
-var errNotFound = errors.New("not found")
-var errTypeError = errors.New("type error")
+var (
+	// ErrNotFound is the error given when reading a key that isn't in the bag
+	ErrNotFound = errors.New("not found")
+
+	// ErrExists is the error given when adding a key that is already in the
+	// bag
+	ErrExists = errors.New("key already exists")
+
+	// ErrNilRef is the error given when adding a ref with a nil pointer
+	ErrNilRef = errors.New("nil ref")
+
+	// ErrFrozen is the error given when adding a key to a frozen bag
+	ErrFrozen = errors.New("bag is frozen")
+)
+
+// TypeError is the error given when reading a key as a different type than
+// the type of the value stored under that key. For keys added with Ref,
+// Stored is the type the pointer points to, since that's the type of the
+// value that reading the key produces.
+type TypeError struct {
+	Key       string
+	Stored    reflect.Type
+	Requested reflect.Type
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("type error: key %q holds %v, not %v", e.Key, e.Stored, e.Requested)
+}
+
+// Reader is anything that values can be read out of with Get and Lookup. It's
+// implemented by Bag, Sync and Scope.
+type Reader interface {
+	lookup(k string) (bagged, bool)
+	keys() []string
+}
+
+// Writer is anything that values can be added to with Add, Ref, Store,
+// StoreRef, Put and PutRef. It's implemented by Bag, Sync and Scope.
+type Writer interface {
+	Reader
+	insert(k string, v bagged) error
+}
 
 // Bag is a read-only collection of values. Consumer can add elements to the
 // bag if and only no element has been added for that key in the past. Elements
@@ -15,63 +60,163 @@ type Bag map[string]bagged
 // Add adds a value to a bag. The provided value can be retrieved from the bag
 // directly. There's really no reason to call this with a pointer but I don't
 // know how to prevent that.
-func Add(b Bag, k string, v interface{}) bool {
-	if b.Has(k) {
-		return false
+func Add(b Writer, k string, v interface{}) bool { return Store(b, k, v) == nil }
+
+// Store is the same as Add, but explains why it refused to add the value:
+// either the key is already present and the error is ErrExists, or the bag is
+// a frozen Sync and the error is ErrFrozen.
+func Store(b Writer, k string, v interface{}) error {
+	if err := b.insert(k, bagged{val: v}); err != nil {
+		return fmt.Errorf("unable to store key %q: %w", k, err)
 	}
-	b[k] = bagged{val: v}
-	return true
+	return nil
 }
 
 // Ref adds a reference to a bag. The provided value must be a pointer. Once
 // added, the pointer is never retrievable from the bag; reading this key from
 // the bag dereferences the pointer at the time of reading.
-func Ref[V any](b Bag, k string, v *V) bool {
-	if b.Has(k) {
-		return false
-	}
+func Ref[V any](b Writer, k string, v *V) bool { return StoreRef(b, k, v) == nil }
 
+// StoreRef is the same as Ref, but explains why it refused to add the
+// reference: either the key is already present and the error is ErrExists,
+// or the pointer is nil and the error is ErrNilRef.
+func StoreRef[V any](b Writer, k string, v *V) error {
 	if v == nil {
-		return false
+		return fmt.Errorf("unable to store ref %q: %w", k, ErrNilRef)
 	}
 
-	b[k] = bagged{val: v, ref: true}
-	return true
+	if err := b.insert(k, bagged{val: v, ref: true}); err != nil {
+		return fmt.Errorf("unable to store ref %q: %w", k, err)
+	}
+	return nil
 }
 
 // Get retrieves a value from a bag. Whether a value was added or a ref was
-// added, you always get a value out.
-func Get[V any](b Bag, k string) (V, error) {
-	bv, ok := b[k]
+// added, you always get a value out. Reading a key that isn't in the bag gives
+// an error matching ErrNotFound, and reading a key as the wrong type gives a
+// *TypeError.
+func Get[V any](b Reader, k string) (V, error) {
+	var zero V
+	bv, ok := b.lookup(k)
 	if !ok {
-		var zero V
-		return zero, errNotFound
+		return zero, fmt.Errorf("unable to get key %q: %w", k, ErrNotFound)
 	}
 
 	if bv.ref {
 		ptr, ok := bv.val.(*V)
 		if !ok {
-			var zero V
-			return zero, errTypeError
+			return zero, typeError[V](k, bv)
 		}
 		return *ptr, nil
 	}
 
 	v, ok := bv.val.(V)
 	if !ok {
-		var zero V
-		return zero, errTypeError
+		// a nil interface value has lost its type by the time it's in the
+		// bag, so it's good for any interface type unless Put recorded one
+		want := typeOf[V]()
+		if bv.val == nil && want.Kind() == reflect.Interface && (bv.typ == nil || bv.typ == want) {
+			return zero, nil
+		}
+		return zero, typeError[V](k, bv)
 	}
 	return v, nil
 }
 
+func typeError[V any](k string, bv bagged) *TypeError {
+	return &TypeError{
+		Key:       k,
+		Stored:    bv.stored(),
+		Requested: typeOf[V](),
+	}
+}
+
+// typeOf gets the type V, even if V is an interface type
+func typeOf[V any]() reflect.Type { return reflect.TypeOf((*V)(nil)).Elem() }
+
 // Has describes whether or not the bag contains the given key
 func (b Bag) Has(k string) bool {
 	_, ok := b[k]
 	return ok
 }
 
+// Keys lists the keys in the bag in sorted order
+func (b Bag) Keys() []string {
+	keys := b.keys()
+	sort.Strings(keys)
+	return keys
+}
+
+// Len is the number of keys in the bag
+func (b Bag) Len() int { return len(b) }
+
+// Iter iterates over the keys of the bag in sorted order
+func (b Bag) Iter() iter.Ator[string] { return iter.Slice(b.Keys()).Iter() }
+
+// Merge combines the contents of two bags into a new bag. Refs stay refs, so
+// reading a ref from the merged bag still dereferences the original pointer.
+// If the two bags have a key in common, Merge fails with an error matching
+// ErrExists.
+func Merge(a, b Reader) (Bag, error) {
+	merged := make(Bag)
+	for _, r := range []Reader{a, b} {
+		for _, k := range r.keys() {
+			bv, _ := r.lookup(k)
+			if err := merged.insert(k, bv); err != nil {
+				return nil, fmt.Errorf("unable to merge key %q: %w", k, err)
+			}
+		}
+	}
+	return merged, nil
+}
+
+func (b Bag) keys() []string {
+	keys := make([]string, 0, len(b))
+	for k := range b {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (b Bag) lookup(k string) (bagged, bool) {
+	bv, ok := b[k]
+	return bv, ok
+}
+
+func (b Bag) insert(k string, v bagged) error {
+	if b.Has(k) {
+		return ErrExists
+	}
+	b[k] = v
+	return nil
+}
+
 type bagged struct {
 	val interface{}
 	ref bool
+
+	// typ is the type of a nil interface value stored with a typed key,
+	// which would otherwise have no type at all
+	typ reflect.Type
+}
+
+// stored is the type of value that reading bv produces. For a nil value that
+// wasn't stored with a typed key, that's nil.
+func (bv bagged) stored() reflect.Type {
+	switch {
+	case bv.ref:
+		return reflect.TypeOf(bv.val).Elem()
+	case bv.val == nil:
+		return bv.typ
+	default:
+		return reflect.TypeOf(bv.val)
+	}
+}
+
+// value gets what reading bv produces, dereferencing it if it's a ref
+func (bv bagged) value() interface{} {
+	if bv.ref {
+		return reflect.ValueOf(bv.val).Elem().Interface()
+	}
+	return bv.val
 }
@@ -9,7 +9,7 @@ func TestEmpty(t *testing.T) {
 	b := make(Bag)
 
 	_, err := Get[string](b, "foo")
-	if !errors.Is(err, errNotFound) {
+	if !errors.Is(err, ErrNotFound) {
 		t.Fatalf("expected not found error, saw %v", err)
 	}
 }
@@ -34,7 +34,7 @@ func TestAdd(t *testing.T) {
 	}
 
 	_, err = Get[int](b, "foo")
-	if !errors.Is(err, errTypeError) {
+	if !isTypeError(err) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
@@ -48,12 +48,12 @@ func TestRef(t *testing.T) {
 	}
 
 	_, err := Get[*string](b, "name")
-	if !errors.Is(err, errTypeError) {
+	if !isTypeError(err) {
 		t.Fatal("retrieving pointer for ref did not fail")
 	}
 
 	_, err = Get[int](b, "name")
-	if !errors.Is(err, errTypeError) {
+	if !isTypeError(err) {
 		t.Fatal("retrieving value of differing type for ref did not fail")
 	}
 
@@ -90,3 +90,44 @@ func TestRef(t *testing.T) {
 		t.Fatalf("unexpected value: %v", readName)
 	}
 }
+
+func isTypeError(err error) bool {
+	var te *TypeError
+	return errors.As(err, &te)
+}
+
+func TestErrors(t *testing.T) {
+	b := make(Bag)
+
+	if err := Store(b, "foo", "bar"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Store(b, "foo", "again"); !errors.Is(err, ErrExists) {
+		t.Fatalf("expected exists error, saw %v", err)
+	}
+	if err := StoreRef(b, "foo", new(string)); !errors.Is(err, ErrExists) {
+		t.Fatalf("expected exists error, saw %v", err)
+	}
+	if err := StoreRef[int](b, "nil", nil); !errors.Is(err, ErrNilRef) {
+		t.Fatalf("expected nil ref error, saw %v", err)
+	}
+
+	_, err := Get[int](b, "foo")
+	var te *TypeError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected type error, saw %v", err)
+	}
+	if te.Key != "foo" || te.Stored.String() != "string" || te.Requested.String() != "int" {
+		t.Fatalf("unexpected type error: %+v", te)
+	}
+	if te.Error() != `type error: key "foo" holds string, not int` {
+		t.Fatalf("unexpected type error message: %v", te)
+	}
+
+	n := 3
+	Ref(b, "n", &n)
+	_, err = Get[*int](b, "n")
+	if !errors.As(err, &te) || te.Stored.String() != "int" || te.Requested.String() != "*int" {
+		t.Fatalf("unexpected ref type error: %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package bag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnregistered is the error given when unmarshaling a key that has no type
+// registered for it
+var ErrUnregistered = errors.New("no type registered for key")
+
+var registry struct {
+	sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// Register records that the key k holds values of type V, so that
+// unmarshaling a bag from JSON decodes the value of k as a V and Get[V] can
+// read it back out. Like gob.Register, Register is meant to be called during
+// initialization. Registering a key a second time with the same type does
+// nothing; registering it with a different type panics.
+func Register[V any](k string) {
+	t := reflect.TypeOf((*V)(nil)).Elem()
+
+	registry.Lock()
+	defer registry.Unlock()
+	if registry.types == nil {
+		registry.types = make(map[string]reflect.Type)
+	}
+	if prev, ok := registry.types[k]; ok && prev != t {
+		panic(fmt.Sprintf("bag: key %q registered as both %v and %v", k, prev, t))
+	}
+	registry.types[k] = t
+}
+
+// RegisterKey registers the name of a typed key with the key's type
+func RegisterKey[V any](k Key[V]) { Register[V](k.name) }
+
+func registered(k string) (reflect.Type, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	t, ok := registry.types[k]
+	return t, ok
+}
+
+// MarshalJSON encodes the bag as a JSON object with one field per key. Refs
+// are written as the value they point to at the time of marshaling.
+func (b Bag) MarshalJSON() ([]byte, error) { return marshal(b) }
+
+// UnmarshalJSON decodes a JSON object into the bag. Every key in the object
+// must have had its type registered with Register or RegisterKey, otherwise
+// UnmarshalJSON fails with an error matching ErrUnregistered. Since there's
+// no pointer to refer to anymore, keys that were refs when the bag was
+// marshaled come back as values, but this makes no difference to Get. Like
+// Add, UnmarshalJSON won't replace a key that the bag already has. Either
+// every key in the object is added to the bag or, if anything is wrong with
+// any of them, none are.
+func (b *Bag) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("unable to unmarshal bag: %w", err)
+	}
+
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	decoded := make(Bag, len(raw))
+	for _, k := range keys {
+		if b.Has(k) {
+			return fmt.Errorf("unable to store key %q: %w", k, ErrExists)
+		}
+		t, ok := registered(k)
+		if !ok {
+			return fmt.Errorf("unable to unmarshal key %q: %w", k, ErrUnregistered)
+		}
+		v := reflect.New(t)
+		if err := json.Unmarshal(raw[k], v.Interface()); err != nil {
+			return fmt.Errorf("unable to unmarshal key %q: %w", k, err)
+		}
+		bv := bagged{val: v.Elem().Interface()}
+		if bv.val == nil {
+			bv.typ = t
+		}
+		decoded[k] = bv
+	}
+
+	if *b == nil {
+		*b = decoded
+		return nil
+	}
+	for k, bv := range decoded {
+		(*b)[k] = bv
+	}
+	return nil
+}
+
+// Describe lists every key in the bag in sorted order, one per line, along
+// with whether it's a value or a ref and the type that reading it produces.
+// E.g.:
+//
+//     name: ref string
+//     request: value int
+func (b Bag) Describe() string { return describe(b) }
+
+// MarshalJSON encodes the bag in the same manner as Bag.MarshalJSON
+func (s *Sync) MarshalJSON() ([]byte, error) { return marshal(s) }
+
+// Describe describes the bag in the same manner as Bag.Describe
+func (s *Sync) Describe() string { return describe(s) }
+
+// MarshalJSON encodes every key that can be read from the scope, including
+// the keys it inherits from its ancestors, in the same manner as
+// Bag.MarshalJSON
+func (s *Scope) MarshalJSON() ([]byte, error) { return marshal(s) }
+
+// Describe describes every key that can be read from the scope in the same
+// manner as Bag.Describe
+func (s *Scope) Describe() string { return describe(s) }
+
+func marshal(r Reader) ([]byte, error) {
+	vals := make(map[string]interface{})
+	for _, k := range r.keys() {
+		bv, _ := r.lookup(k)
+		vals[k] = bv.value()
+	}
+	return json.Marshal(vals)
+}
+
+func describe(r Reader) string {
+	keys := r.keys()
+	sort.Strings(keys)
+
+	var buf strings.Builder
+	for _, k := range keys {
+		bv, _ := r.lookup(k)
+		if bv.ref {
+			fmt.Fprintf(&buf, "%s: ref %v\n", k, bv.stored())
+		} else {
+			fmt.Fprintf(&buf, "%s: value %v\n", k, bv.stored())
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,123 @@
+package bag
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type session struct {
+	ID    string
+	Admin bool
+}
+
+var sessionKey = NewKey[session]("session")
+
+func init() {
+	RegisterKey(sessionKey)
+	Register[int]("attempts")
+	Register[[]string]("tags")
+}
+
+func TestJSON(t *testing.T) {
+	b := make(Bag)
+	Put(b, sessionKey, session{ID: "abc", Admin: true})
+	Add(b, "tags", []string{"x", "y"})
+
+	attempts := 1
+	Ref(b, "attempts", &attempts)
+	attempts = 2
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	want := `{"attempts":2,"session":{"ID":"abc","Admin":true},"tags":["x","y"]}`
+	if string(data) != want {
+		t.Errorf("unexpected json: %s", data)
+	}
+
+	var out Bag
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if s, err := Lookup(out, sessionKey); err != nil || s.ID != "abc" || !s.Admin {
+		t.Errorf("unexpected session: %v, %v", s, err)
+	}
+	if n, err := Get[int](out, "attempts"); err != nil || n != 2 {
+		t.Errorf("expected 2 attempts, saw %d, %v", n, err)
+	}
+	if tags, err := Get[[]string](out, "tags"); err != nil || len(tags) != 2 {
+		t.Errorf("unexpected tags: %v, %v", tags, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"attempts":3}`), &out); !errors.Is(err, ErrExists) {
+		t.Errorf("expected exists error, saw %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"attempts":3,"tags":["z"]}`), &out); !errors.Is(err, ErrExists) {
+		t.Errorf("expected exists error, saw %v", err)
+	}
+	if tags, _ := Get[[]string](out, "tags"); len(tags) != 2 {
+		t.Errorf("a failed unmarshal should leave the bag alone, saw tags %v", tags)
+	}
+
+	partial := make(Bag)
+	if err := json.Unmarshal([]byte(`{"attempts":1,"mystery":2}`), &partial); !errors.Is(err, ErrUnregistered) {
+		t.Errorf("expected unregistered error, saw %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"attempts":1,"tags":"x"}`), &partial); err == nil {
+		t.Error("expected an error unmarshaling a string into a []string")
+	}
+	if partial.Len() != 0 {
+		t.Errorf("a failed unmarshal should add nothing, saw %v", partial.Keys())
+	}
+	if err := json.Unmarshal([]byte(`{"attempts":1}`), &partial); err != nil {
+		t.Errorf("expected a retry to succeed, saw %v", err)
+	}
+
+	var bad Bag
+	if err := json.Unmarshal([]byte(`{"mystery":1}`), &bad); !errors.Is(err, ErrUnregistered) {
+		t.Errorf("expected unregistered error, saw %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"attempts":"three"}`), &bad); err == nil {
+		t.Error("expected an error unmarshaling a string into an int")
+	}
+}
+
+func TestRegisterConflict(t *testing.T) {
+	Register[int]("attempts")
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a key with a second type to panic")
+		}
+	}()
+	Register[string]("attempts")
+}
+
+func TestDescribe(t *testing.T) {
+	name := "Jordan"
+	b := make(Bag)
+	Ref(b, "name", &name)
+	Put(b, requestKey, 3)
+
+	want := "name: ref string\nrequest: value int\n"
+	if got := b.Describe(); got != want {
+		t.Errorf("unexpected description:\n%s", got)
+	}
+
+	child := Child(b)
+	Add(child, "extra", true)
+	want = "extra: value bool\n" + want
+	if got := child.Describe(); got != want {
+		t.Errorf("unexpected description:\n%s", got)
+	}
+
+	s := NewSync()
+	Put(s, sessionKey, session{ID: "abc"})
+	if got := s.Describe(); got != "session: value bag.session\n" {
+		t.Errorf("unexpected description:\n%s", got)
+	}
+	if data, err := json.Marshal(child); err != nil || string(data) != `{"extra":true,"name":"Jordan","request":3}` {
+		t.Errorf("unexpected json: %s, %v", data, err)
+	}
+}
@@ -0,0 +1,43 @@
+package bag
+
+// Key is a typed key for a bag. A Key[V] is only ever used to store and
+// retrieve values of type V, so unlike Get, Lookup can't be asked for the
+// wrong type. Keys share their namespace with the string keys used by Add,
+// Ref and Get: a value put in a bag with NewKey[V]("name") can be read with
+// Get[V](b, "name") and vice-versa.
+type Key[V any] struct {
+	name string
+}
+
+// NewKey creates a key for values of type V. Typically keys are created once,
+// as package-level variables, and shared by everything that reads and writes
+// that value:
+//
+//     var userKey = bag.NewKey[*User]("user")
+func NewKey[V any](name string) Key[V] { return Key[V]{name: name} }
+
+// Name is the string key underlying the typed key
+func (k Key[V]) Name() string { return k.name }
+
+func (k Key[V]) String() string { return k.name }
+
+// Put adds a value to a bag with a typed key. Like Add, Put fails if the key
+// is already present in the bag. Putting a nil value with a key of an
+// interface type records the key's type, so that the bag knows it holds e.g.
+// a nil error and not just a nil.
+func Put[V any](b Writer, k Key[V], v V) bool {
+	bv := bagged{val: v}
+	if bv.val == nil {
+		bv.typ = typeOf[V]()
+	}
+	return b.insert(k.name, bv) == nil
+}
+
+// PutRef adds a reference to a bag with a typed key. Like Ref, reading the
+// key dereferences the pointer at the time of reading.
+func PutRef[V any](b Writer, k Key[V], v *V) bool { return Ref(b, k.name, v) }
+
+// Lookup retrieves a value from a bag with a typed key. Since the key can
+// only have been used to store a V, the only way for Lookup to find a value
+// of another type is if a string key of the same name was used to store it.
+func Lookup[V any](b Reader, k Key[V]) (V, error) { return Get[V](b, k.name) }
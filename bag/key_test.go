@@ -0,0 +1,91 @@
+package bag
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type user struct {
+	name string
+}
+
+var (
+	userKey    = NewKey[*user]("user")
+	requestKey = NewKey[int]("request")
+)
+
+func TestKey(t *testing.T) {
+	b := make(Bag)
+
+	if _, err := Lookup(b, userKey); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found error, saw %v", err)
+	}
+
+	if !Put(b, userKey, &user{name: "Jordan"}) {
+		t.Fatalf("weird put failure")
+	}
+	if Put(b, userKey, &user{name: "again"}) {
+		t.Fatalf("weird put success")
+	}
+
+	u, err := Lookup(b, userKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.name != "Jordan" {
+		t.Fatalf("unexpected value: %v", u)
+	}
+
+	// typed keys and string keys share a namespace
+	if _, err := Get[*user](b, "user"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if Add(b, "user", "a string") {
+		t.Fatalf("string key should collide with typed key")
+	}
+}
+
+func TestKeyRef(t *testing.T) {
+	b := make(Bag)
+
+	id := 1
+	if !PutRef(b, requestKey, &id) {
+		t.Fatal("ref failed")
+	}
+	id = 2
+
+	n, err := Lookup(b, requestKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("unexpected value: %v", n)
+	}
+}
+
+func TestKeyNil(t *testing.T) {
+	b := make(Bag)
+
+	errKey := NewKey[error]("err")
+	if !Put(b, errKey, nil) {
+		t.Fatal("put failed")
+	}
+	if err, lookupErr := Lookup(b, errKey); err != nil || lookupErr != nil {
+		t.Fatalf("expected a nil error, saw %v, %v", err, lookupErr)
+	}
+	if _, err := Get[fmt.Stringer](b, "err"); !isTypeError(err) {
+		t.Fatalf("a nil error should not be readable as a fmt.Stringer, saw %v", err)
+	}
+	if got := b.Describe(); got != "err: value error\n" {
+		t.Fatalf("unexpected description: %q", got)
+	}
+
+	Add(b, "nothing", nil)
+	if v, err := Get[interface{}](b, "nothing"); v != nil || err != nil {
+		t.Fatalf("expected an untyped nil to read as any interface, saw %v, %v", v, err)
+	}
+	if _, err := Get[int](b, "nothing"); !isTypeError(err) {
+		t.Fatalf("expected type error, saw %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package bag
+
+import (
+	"sort"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+// Scope is a bag layered on top of another bag. Reading a key from a scope
+// finds the scope's own value for that key if it has one and falls back to
+// the parent otherwise. Writing to a scope never touches its parent. E.g.,
+// layered configuration might look like:
+//
+//     defaults := make(bag.Bag)
+//     bag.Add(defaults, "timeout", 30*time.Second)
+//
+//     env := bag.Child(defaults)
+//     bag.Add(env, "timeout", 5*time.Second)
+//
+//     req := bag.Child(env)
+//     bag.Get[time.Duration](req, "timeout") // 5s
+//
+// The write-once rule applies per scope: a scope may add a key that its
+// parent already has, hiding the parent's value, but it may only do so once.
+// Like a Bag, a Scope is not safe for concurrent writes; a frozen Sync makes a
+// good parent for scopes that are shared between goroutines.
+type Scope struct {
+	parent Reader
+	local  Bag
+}
+
+// Child creates an empty scope on top of parent
+func Child(parent Reader) *Scope { return &Scope{parent: parent, local: make(Bag)} }
+
+// Parent gets the bag that the scope falls back to
+func (s *Scope) Parent() Reader { return s.parent }
+
+func (s *Scope) lookup(k string) (bagged, bool) {
+	if bv, ok := s.local.lookup(k); ok {
+		return bv, true
+	}
+	if s.parent == nil {
+		return bagged{}, false
+	}
+	return s.parent.lookup(k)
+}
+
+func (s *Scope) insert(k string, v bagged) error {
+	if s.local == nil {
+		s.local = make(Bag)
+	}
+	return s.local.insert(k, v)
+}
+
+func (s *Scope) keys() []string {
+	keys := s.local.keys()
+	if s.parent == nil {
+		return keys
+	}
+	for _, k := range s.parent.keys() {
+		if !s.local.Has(k) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Has describes whether or not the key can be read from the scope, whether it
+// belongs to the scope itself or to one of its ancestors
+func (s *Scope) Has(k string) bool {
+	_, ok := s.lookup(k)
+	return ok
+}
+
+// Keys lists every key that can be read from the scope in sorted order,
+// including keys inherited from its ancestors
+func (s *Scope) Keys() []string {
+	keys := s.keys()
+	sort.Strings(keys)
+	return keys
+}
+
+// Len is the number of distinct keys that can be read from the scope
+func (s *Scope) Len() int { return len(s.keys()) }
+
+// Iter iterates over the keys of the scope in sorted order
+func (s *Scope) Iter() iter.Ator[string] { return iter.Slice(s.Keys()).Iter() }
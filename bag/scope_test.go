@@ -0,0 +1,96 @@
+package bag
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+func TestScope(t *testing.T) {
+	defaults := make(Bag)
+	Add(defaults, "timeout", 30)
+	Add(defaults, "retries", 3)
+
+	env := Child(defaults)
+	if !Add(env, "timeout", 5) {
+		t.Fatal("a child should be able to hide a key of its parent")
+	}
+	if Add(env, "timeout", 6) {
+		t.Fatal("a child should only be able to add a key once")
+	}
+
+	name := "req-1"
+	req := Child(env)
+	Ref(req, "name", &name)
+
+	cases := []struct {
+		from Reader
+		key  string
+		want int
+	}{
+		{defaults, "timeout", 30},
+		{env, "timeout", 5},
+		{req, "timeout", 5},
+		{req, "retries", 3},
+	}
+	for _, c := range cases {
+		n, err := Get[int](c.from, c.key)
+		if err != nil || n != c.want {
+			t.Errorf("expected %s to be %d, saw %d, %v", c.key, c.want, n, err)
+		}
+	}
+
+	if _, err := Get[string](env, "name"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("writes to a child should not reach its parent, saw %v", err)
+	}
+	if defaults.Has("name") || !req.Has("retries") {
+		t.Error("unexpected result of Has")
+	}
+
+	if got := fmt.Sprint(req.Keys()); got != "[name retries timeout]" {
+		t.Errorf("unexpected keys: %s", got)
+	}
+	if req.Len() != 3 || env.Len() != 2 {
+		t.Errorf("unexpected lengths: %d, %d", req.Len(), env.Len())
+	}
+
+	var keys []string
+	for k, it := iter.Start[string](req); it.Next(&k); {
+		keys = append(keys, k)
+	}
+	if got := fmt.Sprint(keys); got != "[name retries timeout]" {
+		t.Errorf("unexpected iterated keys: %s", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := make(Bag)
+	Add(a, "one", 1)
+
+	count := 2
+	b := make(Bag)
+	Ref(b, "two", &count)
+
+	merged, err := Merge(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	count = 3
+	if n, err := Get[int](merged, "two"); err != nil || n != 3 {
+		t.Errorf("merged refs should stay refs, saw %d, %v", n, err)
+	}
+	if got := fmt.Sprint(merged.Keys()); got != "[one two]" {
+		t.Errorf("unexpected keys: %s", got)
+	}
+
+	Add(b, "one", 100)
+	if _, err := Merge(a, b); !errors.Is(err, ErrExists) {
+		t.Errorf("expected exists error, saw %v", err)
+	}
+
+	if _, err := Merge(a, Child(a)); !errors.Is(err, ErrExists) {
+		t.Errorf("expected exists error merging a child with its parent, saw %v", err)
+	}
+}
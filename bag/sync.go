@@ -0,0 +1,119 @@
+package bag
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+// Sync is a bag that is safe for concurrent use. Values are added and read
+// with the same functions as a Bag: Add, Ref, Get and friends.
+//
+// A Sync is meant to be filled in while a program starts up and then frozen
+// with Freeze, after which it can never change again. Until it's frozen,
+// every access takes a lock. Once it's frozen, reads don't lock at all.
+//
+// Note that while a Sync protects itself, it can't protect the pointers given
+// to Ref: writing through such a pointer while another goroutine reads its
+// key is a data race. Freezing the bag resolves that too, since freezing
+// copies the value behind every ref one last time and keeps the copy.
+type Sync struct {
+	mu     sync.RWMutex
+	bag    Bag
+	frozen atomic.Pointer[Bag]
+}
+
+// NewSync creates an empty bag for concurrent use
+func NewSync() *Sync { return &Sync{bag: make(Bag)} }
+
+func (s *Sync) lookup(k string) (bagged, bool) {
+	if frozen := s.frozen.Load(); frozen != nil {
+		return frozen.lookup(k)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bag.lookup(k)
+}
+
+func (s *Sync) keys() []string {
+	if frozen := s.frozen.Load(); frozen != nil {
+		return frozen.keys()
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.bag.keys()
+}
+
+func (s *Sync) insert(k string, v bagged) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.frozen.Load() != nil {
+		return ErrFrozen
+	}
+	if s.bag == nil {
+		s.bag = make(Bag)
+	}
+	return s.bag.insert(k, v)
+}
+
+// Has describes whether or not the bag contains the given key
+func (s *Sync) Has(k string) bool {
+	_, ok := s.lookup(k)
+	return ok
+}
+
+// Keys lists the keys in the bag in sorted order
+func (s *Sync) Keys() []string {
+	keys := s.keys()
+	sort.Strings(keys)
+	return keys
+}
+
+// Len is the number of keys in the bag
+func (s *Sync) Len() int { return len(s.keys()) }
+
+// Iter iterates over the keys in the bag in sorted order, as they were at the
+// time Iter was called
+func (s *Sync) Iter() iter.Ator[string] { return iter.Slice(s.Keys()).Iter() }
+
+// Freeze makes the bag permanently read-only: every later attempt to add to
+// it fails with ErrFrozen. Every ref in the bag is pointed at a private copy
+// of the value it pointed to at the time of freezing, so that the values in a
+// frozen bag can never change. Reading a key gives the same value, of the
+// same type, just before and just after freezing. Freezing a frozen bag does
+// nothing.
+func (s *Sync) Freeze() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.frozen.Load() != nil {
+		return
+	}
+
+	frozen := make(Bag, len(s.bag))
+	for k, bv := range s.bag {
+		if bv.ref {
+			bv = frozenRef(bv)
+		}
+		frozen[k] = bv
+	}
+	s.bag = frozen
+	s.frozen.Store(&frozen)
+}
+
+// frozenRef gives a ref to a copy of the value that the ref bv points to.
+// Since the copy has the same type as the original, even a nil interface
+// value reads back exactly as it did through the original pointer.
+func frozenRef(bv bagged) bagged {
+	orig := reflect.ValueOf(bv.val).Elem()
+	p := reflect.New(orig.Type())
+	p.Elem().Set(orig)
+	return bagged{val: p.Interface(), ref: true}
+}
+
+// Frozen is true once the bag has been frozen
+func (s *Sync) Frozen() bool { return s.frozen.Load() != nil }
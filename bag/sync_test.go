@@ -0,0 +1,91 @@
+package bag
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSync(t *testing.T) {
+	s := NewSync()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				k := fmt.Sprintf("%d-%d", i, j)
+				if !Add(s, k, j) {
+					t.Errorf("unable to add %s", k)
+				}
+				if _, err := Get[int](s, k); err != nil {
+					t.Errorf("unable to get %s: %v", k, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := Store(s, "3-7", 0); !errors.Is(err, ErrExists) {
+		t.Fatalf("expected exists error, saw %v", err)
+	}
+	n, err := Get[int](s, "3-7")
+	if err != nil || n != 7 {
+		t.Fatalf("expected 7, saw %d, %v", n, err)
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	var s Sync
+
+	name := "Jordan"
+	if err := StoreRef(&s, "name", &name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	Put(&s, requestKey, 3)
+
+	var e error
+	Ref(&s, "err", &e)
+	if v, err := Get[error](&s, "err"); v != nil || err != nil {
+		t.Fatalf("expected a nil error, saw %v, %v", v, err)
+	}
+
+	s.Freeze()
+	s.Freeze()
+	if !s.Frozen() {
+		t.Fatal("bag should be frozen")
+	}
+
+	if err := Store(&s, "late", true); !errors.Is(err, ErrFrozen) {
+		t.Fatalf("expected frozen error, saw %v", err)
+	}
+	if Ref(&s, "late", &name) {
+		t.Fatal("ref should fail on a frozen bag")
+	}
+
+	name = "changed"
+	readName, err := Get[string](&s, "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if readName != "Jordan" {
+		t.Fatalf("refs should be fixed at freeze time, saw %v", readName)
+	}
+	if v, err := Get[error](&s, "err"); v != nil || err != nil {
+		t.Fatalf("a ref to a nil error should read the same after freezing, saw %v, %v", v, err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n, err := Lookup(&s, requestKey); err != nil || n != 3 {
+				t.Errorf("expected 3, saw %d, %v", n, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,6 +1,10 @@
 package iter
 
-import "constraints"
+import (
+	"constraints"
+
+	"github.com/jordanorelli/generic/tuple"
+)
 
 // Able is anything that is iter.Able
 type Able[T any] interface {
@@ -101,6 +105,30 @@ func Map[T, Z any](src Able[T], f func(T) Z) Able[Z] {
 	return mapIter[T, Z]{fn: f, src: src.Iter()}
 }
 
+type zipIter[T, Z any] struct {
+	left  Ator[T]
+	right Ator[Z]
+}
+
+func (it zipIter[T, Z]) Next(v *tuple.Pair[T, Z]) bool {
+	var p tuple.Pair[T, Z]
+	if !it.left.Next(&p.Left) || !it.right.Next(&p.Right) {
+		return false
+	}
+	*v = p
+	return true
+}
+
+func (it zipIter[T, Z]) Iter() Ator[tuple.Pair[T, Z]] {
+	return zipIter[T, Z]{left: it.left.Iter(), right: it.right.Iter()}
+}
+
+// Zip iterates two iterables side by side, producing pairs of their values.
+// Iteration stops as soon as either side is exhausted.
+func Zip[T, Z any](left Able[T], right Able[Z]) Able[tuple.Pair[T, Z]] {
+	return zipIter[T, Z]{left: left.Iter(), right: right.Iter()}
+}
+
 // Discarded Iterator types:
 //
 // type Ator[T any] interface {
@@ -17,3 +17,16 @@ func TestSlice(t *testing.T) {
 		t.Log("fart " + n)
 	}
 }
+
+func TestZip(t *testing.T) {
+	names := Slice([]string{"alice", "bob", "carol"})
+	ages := Slice([]int{30, 40})
+
+	n := 0
+	for p, it := Start(Zip(names, ages)); it.Next(&p); n++ {
+		t.Log(p)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 pairs but saw %d instead", n)
+	}
+}
@@ -0,0 +1,129 @@
+package list
+
+import (
+	"sync/atomic"
+)
+
+// ConcurrentStack is a stack that is safe for concurrent use without locks.
+// It's a Treiber stack: the head of a chain of list nodes is swapped with a
+// compare-and-swap, so pushing and popping never block one another, they only
+// retry. The zero value is an empty stack ready to use.
+type ConcurrentStack[T any] struct {
+	head atomic.Pointer[node[T]]
+}
+
+// Push adds an element to the top of the stack
+func (s *ConcurrentStack[T]) Push(v T) {
+	n := &node[T]{val: v}
+	for {
+		n.next = s.head.Load()
+		if s.head.CompareAndSwap(n.next, n) {
+			return
+		}
+	}
+}
+
+// TryPop removes the top element of the stack and returns it. If the stack is
+// empty, TryPop returns the zero-value for the type T and false.
+func (s *ConcurrentStack[T]) TryPop() (T, bool) {
+	for {
+		n := s.head.Load()
+		if n == nil {
+			var zero T
+			return zero, false
+		}
+		// nodes are never modified once they've been pushed, so reading
+		// n.next here is safe even if n is concurrently popped by somebody
+		// else; our CAS will fail in that case and we go around again.
+		if s.head.CompareAndSwap(n, n.next) {
+			return n.val, true
+		}
+	}
+}
+
+// Empty is true if the stack held no elements at the time of calling
+func (s *ConcurrentStack[T]) Empty() bool { return s.head.Load() == nil }
+
+// qnode is a list node whose link can be updated atomically
+type qnode[T any] struct {
+	val  T
+	next atomic.Pointer[qnode[T]]
+}
+
+// Queue is a first-in first-out queue that is safe for concurrent use without
+// locks. It's a Michael-Scott queue: head always points at a sentinel node
+// whose successor is the front of the queue, and tail points at or near the
+// last node. Any goroutine that finds tail lagging behind helps move it
+// forward, so no goroutine ever waits on another. The zero value is an empty
+// queue ready to use.
+type Queue[T any] struct {
+	head atomic.Pointer[qnode[T]]
+	tail atomic.Pointer[qnode[T]]
+}
+
+// init installs the sentinel node the first time the queue is used
+func (q *Queue[T]) init() {
+	if q.tail.Load() != nil {
+		return
+	}
+	q.head.CompareAndSwap(nil, new(qnode[T]))
+	q.tail.CompareAndSwap(nil, q.head.Load())
+}
+
+// Enqueue adds an element to the back of the queue
+func (q *Queue[T]) Enqueue(v T) {
+	q.init()
+	n := &qnode[T]{val: v}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// tail is lagging; help it along and try again
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			return
+		}
+	}
+}
+
+// TryDequeue removes the element at the front of the queue and returns it. If
+// the queue is empty, TryDequeue returns the zero-value for the type T and
+// false.
+func (q *Queue[T]) TryDequeue() (T, bool) {
+	q.init()
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		if head == tail {
+			// something was enqueued but tail hasn't caught up yet
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// next becomes the new sentinel; its value is read before the CAS
+		// because once the CAS succeeds another dequeuer may own it.
+		v := next.val
+		if q.head.CompareAndSwap(head, next) {
+			return v, true
+		}
+	}
+}
+
+// Empty is true if the queue held no elements at the time of calling
+func (q *Queue[T]) Empty() bool {
+	head := q.head.Load()
+	return head == nil || head.next.Load() == nil
+}
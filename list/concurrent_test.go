@@ -0,0 +1,149 @@
+package list
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentStack(t *testing.T) {
+	var s ConcurrentStack[int]
+	if _, ok := s.TryPop(); ok {
+		t.Fatalf("popping an empty stack should fail")
+	}
+
+	s.Push(1)
+	s.Push(2)
+	if n, ok := s.TryPop(); !ok || n != 2 {
+		t.Errorf("expected to pop 2 but saw %d, %t", n, ok)
+	}
+
+	const workers, each = 8, 1000
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				s.Push(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var popped int64
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if _, ok := s.TryPop(); !ok {
+					return
+				}
+				atomic.AddInt64(&popped, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	eq(t, workers*each+1, int(popped))
+	eq(t, true, s.Empty())
+}
+
+func TestQueue(t *testing.T) {
+	var q Queue[int]
+	if _, ok := q.TryDequeue(); ok {
+		t.Fatalf("dequeueing an empty queue should fail")
+	}
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	if n, ok := q.TryDequeue(); !ok || n != 1 {
+		t.Errorf("expected to dequeue 1 but saw %d, %t", n, ok)
+	}
+	if n, ok := q.TryDequeue(); !ok || n != 2 {
+		t.Errorf("expected to dequeue 2 but saw %d, %t", n, ok)
+	}
+	eq(t, true, q.Empty())
+
+	// every producer enqueues an increasing sequence, so every consumer must
+	// see each producer's values in increasing order
+	const producers, each = 4, 2000
+	type item struct{ producer, seq int }
+	var items Queue[item]
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				items.Enqueue(item{p, i})
+			}
+		}(p)
+	}
+
+	seen := make([]int, producers)
+	for n := 0; n < producers*each; {
+		it, ok := items.TryDequeue()
+		if !ok {
+			continue
+		}
+		if it.seq != seen[it.producer] {
+			t.Fatalf("producer %d: expected seq %d but saw %d", it.producer, seen[it.producer], it.seq)
+		}
+		seen[it.producer]++
+		n++
+	}
+	wg.Wait()
+	eq(t, true, items.Empty())
+}
+
+// lockedList is the mutex-guarded List that the lock-free containers are
+// benchmarked against
+type lockedList[T any] struct {
+	sync.Mutex
+	l List[T]
+}
+
+func benchmarkLocked(b *testing.B) {
+	var s lockedList[int]
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Lock()
+			s.l.Push(1)
+			s.Unlock()
+			s.Lock()
+			s.l.Pop()
+			s.Unlock()
+		}
+	})
+}
+
+func BenchmarkStack(b *testing.B) {
+	b.Run("mutex", benchmarkLocked)
+
+	b.Run("treiber", func(b *testing.B) {
+		var s ConcurrentStack[int]
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				s.Push(1)
+				s.TryPop()
+			}
+		})
+	})
+}
+
+func BenchmarkQueue(b *testing.B) {
+	b.Run("mutex", benchmarkLocked)
+
+	b.Run("michael-scott", func(b *testing.B) {
+		var q Queue[int]
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				q.Enqueue(1)
+				q.TryDequeue()
+			}
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// slice copies the values of the list into a new slice
+func (l List[T]) slice() []T {
+	var s []T
+	for n := l.head; n != nil; n = n.next {
+		s = append(s, n.val)
+	}
+	return s
+}
+
+// MarshalJSON encodes the list as a JSON array. An empty list is encoded as
+// an empty array and never as null.
+func (l List[T]) MarshalJSON() ([]byte, error) {
+	s := l.slice()
+	if s == nil {
+		s = []T{}
+	}
+	return json.Marshal(s)
+}
+
+// UnmarshalJSON decodes a JSON array into the list, replacing its contents.
+// A JSON null decodes to an empty list.
+func (l *List[T]) UnmarshalJSON(b []byte) error {
+	var s []T
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("unable to unmarshal list: %w", err)
+	}
+	*l = Make(s...)
+	return nil
+}
+
+// GobEncode encodes the list for the encoding/gob package
+func (l List[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(l.slice()); err != nil {
+		return nil, fmt.Errorf("unable to gob encode list: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a list produced by GobEncode, replacing the contents of
+// the list.
+func (l *List[T]) GobDecode(b []byte) error {
+	var s []T
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&s); err != nil {
+		return fmt.Errorf("unable to gob decode list: %w", err)
+	}
+	*l = Make(s...)
+	return nil
+}
+
+// Parse is the inverse of String: it reads a list written like [1, 2, 3].
+// Only lists of primitive types (bools, numbers and strings) can be parsed.
+// Since String does not quote its elements, a list of strings that contain
+// ", " cannot be parsed back into the same list.
+func Parse[T any](s string) (List[T], error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return List[T]{}, fmt.Errorf("unable to parse list %q: missing brackets", s)
+	}
+
+	s = s[1 : len(s)-1]
+	if s == "" {
+		return List[T]{}, nil
+	}
+
+	parts := strings.Split(s, ", ")
+	vals := make([]T, len(parts))
+	for i, part := range parts {
+		if err := parseElem(part, &vals[i]); err != nil {
+			return List[T]{}, fmt.Errorf("unable to parse list element %d: %w", i, err)
+		}
+	}
+	return Make(vals...), nil
+}
+
+// parseElem parses the text form of a single primitive value into dest
+func parseElem[T any](s string, dest *T) error {
+	v := reflect.ValueOf(dest).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot parse values of type %v", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package list
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	type job struct {
+		Name  string
+		Steps List[string]
+	}
+
+	b, err := json.Marshal(job{Name: "deploy", Steps: Make("build", "test", "ship")})
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	eq(t, `{"Name":"deploy","Steps":["build","test","ship"]}`, string(b))
+
+	var j job
+	if err := json.Unmarshal(b, &j); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	eq(t, "[build, test, ship]", j.Steps.String())
+
+	b, err = json.Marshal(List[int]{})
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	eq(t, "[]", string(b))
+
+	var bad List[int]
+	if err := json.Unmarshal([]byte(`["one"]`), &bad); err == nil {
+		t.Errorf("expected an error unmarshaling strings into a list of ints")
+	}
+}
+
+func TestGob(t *testing.T) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(Make(1, 2, 3)); err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+
+	var l List[int]
+	if err := gob.NewDecoder(&buf).Decode(&l); err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	eq(t, "[1, 2, 3]", l.String())
+}
+
+func TestParse(t *testing.T) {
+	nums, err := Parse[int](Make(1, -2, 3).String())
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	eq(t, "[1, -2, 3]", nums.String())
+
+	words, err := Parse[string]("[alice, bob]")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	eq(t, "bob", words.At(1))
+
+	empty, err := Parse[float64]("[]")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	eq(t, true, empty.Empty())
+
+	if _, err := Parse[int8]("[1, 300]"); err == nil {
+		t.Errorf("expected an overflow error parsing 300 as int8")
+	}
+	if _, err := Parse[int]("1, 2"); err == nil {
+		t.Errorf("expected an error parsing a list without brackets")
+	}
+	if _, err := Parse[struct{}]("[{}]"); err == nil {
+		t.Errorf("expected an error parsing a list of structs")
+	}
+}
@@ -2,11 +2,12 @@ package list
 
 import (
 	"strings"
-	"sync"
 	"constraints"
 	"fmt"
 
 	"github.com/jordanorelli/generic/iter"
+	"github.com/jordanorelli/generic/opt"
+	"github.com/jordanorelli/generic/tuple"
 )
 
 type node[T any] struct {
@@ -60,6 +61,39 @@ func (l List[T]) At(i int) T {
 	return v
 }
 
+// GetOpt is the same as At, but gives an empty value instead of the
+// zero-value for T if the list has no i'th element
+func (l List[T]) GetOpt(i int) opt.Val[T] {
+	for n, at := l.head, 0; n != nil; n, at = n.next, at+1 {
+		if at == i {
+			return opt.Some(n.val)
+		}
+	}
+	return opt.None[T]()
+}
+
+// First gives the first element of the list, or an empty value if the list
+// is empty
+func (l List[T]) First() opt.Val[T] {
+	if l.head == nil {
+		return opt.None[T]()
+	}
+	return opt.Some(l.head.val)
+}
+
+// Last gives the last element of the list, or an empty value if the list is
+// empty. Last has to walk the entire list.
+func (l List[T]) Last() opt.Val[T] {
+	if l.head == nil {
+		return opt.None[T]()
+	}
+	n := l.head
+	for n.next != nil {
+		n = n.next
+	}
+	return opt.Some(n.val)
+}
+
 // Push adds an element to the front of the list
 func (l *List[T]) Push(v T) {
 	l.head = &node[T]{
@@ -150,6 +184,23 @@ func Max[T constraints.Ordered](l List[T]) T {
  	return v
 }
 
+// Min gets the smallest value in the list. If the list is empty, Min returns
+// the zero-value for the type T.
+func Min[T constraints.Ordered](l List[T]) T {
+	if l.Empty() {
+		var v T
+		return v
+	}
+
+	v := l.head.val
+	for n := l.head.next; n != nil; n = n.next {
+		if n.val < v {
+			v = n.val
+		}
+	}
+	return v
+}
+
 // Map exists as a method to permit chaining in the event that your input
 // function maps T -> T. Since methods cannot have type parameters, mapping a
 // function that transforms T -> Z is not possible as a method.
@@ -173,62 +224,6 @@ func Map[T any, Z any](l List[T], f func(T) Z) List[Z] {
 	return mapped
 }
 
-type numbered[T any] struct {
-	val T
-	i int
-}
-
-func waitNClose[T any](wg *sync.WaitGroup, c chan T) {
-	wg.Wait()
-	close(c)
-}
-
-// Run is the same as Map, but is run concurrently. The function f will be run
-// for every element of l in its own goroutine. The results of running f on
-// each of the inputs will be stored into a new list in an order-preserving
-// manner.
-func Run[T any, Z any](l List[T], f func(T) Z) List[Z] {
-	if l.Empty() {
-		var empty List[Z]
-		return empty
-	}
-
-	// surprise: type declarations are not allowed inside of generic functions
-	//
-	// type numbered[T any] struct {
-	// 	val T
-	// 	i int
-	// }
-
-	var wg sync.WaitGroup
-	c := make(chan numbered[Z])
-
-	i := 0
-	for n := l.head; n != nil; n = n.next{
-		wg.Add(1)
-		go func(v T, i int) {
-			defer wg.Done()
-			c <- numbered[Z]{val: f(v), i: i}
-		}(n.val, i)
-		i++
-	}
-
-	mem := make([]Z, i)
-	go waitNClose(&wg, c)
-	for z := range c {
-		mem[z.i] = z.val
-	}
-
-	var results List[Z]
-	for i, _ := range mem {
-		results.head = &node[Z]{
-			val: mem[i],
-			next: results.head,
-		}
-	}
-	return results
-}
-
 // Filter applies a predicate function f to each element of the list and
 // returns a new list containing the values of the elements that passed the
 // predicate
@@ -256,22 +251,7 @@ func (l List[T]) Filter(f func(T) bool) List[T] {
 	return passed
 }
 
-type Pair[T any, Z any] struct {
-	Left T
-	Right Z
-}
-
-// Zip takes two lists and joins them to create a list of pairs. It's the same
-// as the python zip function, and totally stupid and Pair should not be in
-// this package but I'm testing the iterable interfaces and this shows they are
-// good, actually
-func Zip[T any, Z any](left List[T], right List[Z]) List[Pair[T, Z]] {
-	lit, rit := left.Iter(), right.Iter()
-	var out List[Pair[T, Z]]
-
-	var next Pair[T, Z]
-	for lit.Next(&next.Left) && rit.Next(&next.Right) {
-		out.head = &node[Pair[T, Z]]{val: next, next: out.head}
-	}
-	return out
-}
+// Pair is the type of the elements produced by Zip. It used to be defined
+// here, but it's more generally useful than lists so it lives in the tuple
+// package now; this alias keeps existing code that says list.Pair working.
+type Pair[T any, Z any] = tuple.Pair[T, Z]
@@ -145,3 +145,22 @@ func TestIter(t *testing.T) {
 		t.Logf("%v", n)
 	}
 }
+
+func TestOpt(t *testing.T) {
+	l := Make(0, 1, 2)
+
+	if n, ok := l.GetOpt(0).Open(); !ok || n != 0 {
+		t.Errorf("expected a zero that is present but saw %d, %t", n, ok)
+	}
+	if l.GetOpt(3).IsSome() || l.GetOpt(-1).IsSome() {
+		t.Errorf("expected out of range elements to be empty")
+	}
+
+	eq(t, 0, l.First().MustGet())
+	eq(t, 2, l.Last().MustGet())
+
+	var empty List[int]
+	if empty.First().IsSome() || empty.Last().IsSome() {
+		t.Errorf("empty list should have no first or last element")
+	}
+}
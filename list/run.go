@@ -0,0 +1,218 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrorMode determines how RunContext reacts to an element failing
+type ErrorMode int
+
+const (
+	// FailFast stops dispatching work as soon as any element fails and
+	// cancels the context given to the elements that are still running. The
+	// first error observed is returned.
+	FailFast ErrorMode = iota
+
+	// CollectAll runs every element regardless of failures and reports all of
+	// the failures together as an Errors value.
+	CollectAll
+)
+
+// RunOpts configures RunContext. The zero value is usable: it runs one worker
+// per available CPU and fails fast.
+type RunOpts struct {
+	// Workers is the maximum number of goroutines that will run f at the same
+	// time. A value less than 1 means runtime.GOMAXPROCS(0).
+	Workers int
+
+	// Errors decides what happens when f returns an error or panics
+	Errors ErrorMode
+}
+
+// RunError is an error produced while running the element at position Index
+// of the input list
+type RunError struct {
+	Index int
+	Err   error
+}
+
+func (e *RunError) Error() string { return fmt.Sprintf("element %d: %v", e.Index, e.Err) }
+
+func (e *RunError) Unwrap() error { return e.Err }
+
+// Errors is every error collected by RunContext in CollectAll mode, sorted by
+// the index of the element that produced it
+type Errors []*RunError
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d errors: ", len(e))
+	for i, err := range e {
+		if i > 0 {
+			buf.WriteString("; ")
+		}
+		buf.WriteString(err.Error())
+	}
+	return buf.String()
+}
+
+// Unwrap exposes every contained error to errors.Is and errors.As
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i := range e {
+		errs[i] = e[i]
+	}
+	return errs
+}
+
+// PanicError is the error produced when the function given to RunContext
+// panics. Value is whatever was passed to panic and Stack is the stack of the
+// panicking goroutine.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string { return fmt.Sprintf("panic: %v", e.Value) }
+
+// Unwrap gives access to the panic value when somebody panicked with an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Run is the same as Map, but is run concurrently. The function f is run on a
+// bounded pool of goroutines, one per available CPU. The results of running f
+// on each of the inputs will be stored into a new list in an order-preserving
+// manner. A panic in f is re-raised in the caller's goroutine.
+func Run[T any, Z any](l List[T], f func(T) Z) List[Z] {
+	out, err := RunContext(context.Background(), l, func(_ context.Context, v T) (Z, error) {
+		return f(v), nil
+	}, RunOpts{})
+	if err != nil {
+		var p *PanicError
+		if errors.As(err, &p) {
+			panic(p.Value)
+		}
+		panic(err)
+	}
+	return out
+}
+
+// RunContext is like Run, but f may fail and the whole run may be cancelled
+// through ctx. At most opts.Workers elements are processed at the same time,
+// and the output list is in the same order as the input list, exactly as it
+// would be with Map.
+//
+// A panic inside of f is recovered and turned into a *PanicError for that
+// element. In FailFast mode the first error is returned along with an empty
+// list. In CollectAll mode every element is run; the returned list holds the
+// zero value of Z at every position that failed and the error is an Errors
+// value. If ctx is cancelled before every element has been run, ctx.Err() is
+// returned along with an empty list.
+func RunContext[T any, Z any](ctx context.Context, l List[T], f func(context.Context, T) (Z, error), opts RunOpts) (List[Z], error) {
+	var in []T
+	for n := l.head; n != nil; n = n.next {
+		in = append(in, n.val)
+	}
+	if len(in) == 0 {
+		return List[Z]{}, nil
+	}
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(in) {
+		workers = len(in)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		errs  Errors
+		first error
+		done  int
+	)
+	fail := func(i int, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		re := &RunError{Index: i, Err: err}
+		if first == nil {
+			first = re
+		}
+		errs = append(errs, re)
+		if opts.Errors == FailFast {
+			cancel()
+		}
+	}
+
+	out := make([]Z, len(in))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				z, err := runOne(ctx, f, in[i])
+				if err != nil {
+					fail(i, err)
+					continue
+				}
+				out[i] = z
+				mu.Lock()
+				done++
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for i := range in {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if opts.Errors == FailFast && first != nil {
+		return List[Z]{}, first
+	}
+	if done+len(errs) < len(in) {
+		return List[Z]{}, ctx.Err()
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+		return Make(out...), errs
+	}
+	return Make(out...), nil
+}
+
+// runOne runs f on a single value, turning a panic into a *PanicError
+func runOne[T any, Z any](ctx context.Context, f func(context.Context, T) (Z, error), v T) (z Z, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return f(ctx, v)
+}
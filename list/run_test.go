@@ -0,0 +1,125 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunOrder(t *testing.T) {
+	l := Make(5, 4, 3, 2, 1)
+	out := Run(l, func(n int) int {
+		time.Sleep(time.Duration(n) * time.Millisecond)
+		return n * 10
+	})
+	eq(t, Map(l, mult(10)).String(), out.String())
+}
+
+func TestRunContextWorkers(t *testing.T) {
+	var running, peak int32
+	f := func(ctx context.Context, n int) (int, error) {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if now <= p || atomic.CompareAndSwapInt32(&peak, p, now) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return n + 1, nil
+	}
+
+	var l List[int]
+	for i := 99; i >= 0; i-- {
+		l.Push(i)
+	}
+
+	out, err := RunContext(context.Background(), l, f, RunOpts{Workers: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if peak > 3 {
+		t.Errorf("expected at most 3 concurrent workers but saw %d", peak)
+	}
+	eq(t, 100, out.Len())
+	eq(t, 1, out.Head())
+	eq(t, 100, out.At(99))
+}
+
+var errOdd = errors.New("odd")
+
+func failOdd(ctx context.Context, n int) (string, error) {
+	if n%2 == 1 {
+		return "", errOdd
+	}
+	return fmt.Sprint(n), nil
+}
+
+func TestRunContextFailFast(t *testing.T) {
+	out, err := RunContext(context.Background(), Make(2, 4, 5, 6), failOdd, RunOpts{Workers: 1})
+	if !errors.Is(err, errOdd) {
+		t.Fatalf("expected odd error but saw %v", err)
+	}
+	var re *RunError
+	if !errors.As(err, &re) || re.Index != 2 {
+		t.Errorf("expected a RunError at index 2 but saw %v", err)
+	}
+	if !out.Empty() {
+		t.Errorf("expected empty output on failure but saw %v", out)
+	}
+}
+
+func TestRunContextCollectAll(t *testing.T) {
+	out, err := RunContext(context.Background(), Make(1, 2, 3, 4), failOdd, RunOpts{Errors: CollectAll})
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected Errors but saw %v", err)
+	}
+	if len(errs) != 2 || errs[0].Index != 0 || errs[1].Index != 2 {
+		t.Errorf("expected errors at index 0 and 2 but saw %v", errs)
+	}
+	if !errors.Is(err, errOdd) {
+		t.Errorf("expected collected errors to match errOdd")
+	}
+	eq(t, "[, 2, , 4]", out.String())
+}
+
+func TestRunContextPanic(t *testing.T) {
+	_, err := RunContext(context.Background(), Make(1, 2, 3), func(_ context.Context, n int) (int, error) {
+		if n == 2 {
+			panic("two")
+		}
+		return n, nil
+	}, RunOpts{})
+
+	var p *PanicError
+	if !errors.As(err, &p) {
+		t.Fatalf("expected a panic error but saw %v", err)
+	}
+	if p.Value != "two" {
+		t.Errorf("expected panic value %q but saw %v", "two", p.Value)
+	}
+}
+
+func TestRunContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var ran int32
+	_, err := RunContext(ctx, Make(1, 2, 3, 4, 5, 6, 7, 8), func(ctx context.Context, n int) (int, error) {
+		if atomic.AddInt32(&ran, 1) == 2 {
+			cancel()
+		}
+		return n, nil
+	}, RunOpts{Workers: 1})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation but saw %v", err)
+	}
+	if ran == 8 {
+		t.Errorf("expected cancellation to stop dispatching work")
+	}
+}
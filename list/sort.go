@@ -0,0 +1,103 @@
+package list
+
+import (
+	"constraints"
+)
+
+func less[T constraints.Ordered](a, b T) bool { return a < b }
+
+// Sort returns a sorted copy of the list l. The sort is a stable merge sort,
+// which is the natural sort for a singly-linked list: it runs in O(n log n)
+// time and works by relinking nodes, so the only allocations are the nodes of
+// the copy itself. The original list is not modified.
+func Sort[T constraints.Ordered](l List[T]) List[T] { return SortBy(l, less[T]) }
+
+// SortBy is the same as Sort, but orders the elements with the provided less
+// function instead of the < operator. Elements for which neither less(a, b)
+// nor less(b, a) is true keep their original relative order.
+func SortBy[T any](l List[T], less func(a, b T) bool) List[T] {
+	return List[T]{head: mergeSort(l.clone().head, less)}
+}
+
+// MergeSorted merges two lists that are already sorted into a single sorted
+// list. When elements of left and right are equal, the elements of left come
+// first. Neither input list is modified.
+func MergeSorted[T constraints.Ordered](left, right List[T]) List[T] {
+	return List[T]{head: merge(left.clone().head, right.clone().head, less[T])}
+}
+
+// IsSorted reports whether the list is in ascending order
+func IsSorted[T constraints.Ordered](l List[T]) bool {
+	if l.Empty() {
+		return true
+	}
+	for n := l.head; n.next != nil; n = n.next {
+		if n.next.val < n.val {
+			return false
+		}
+	}
+	return true
+}
+
+// Dedup returns a copy of the list in which each run of adjacent equal
+// elements is replaced by a single element. On a sorted list this removes
+// every duplicate.
+func Dedup[T comparable](l List[T]) List[T] {
+	var out List[T]
+	var last *node[T]
+	for n := l.head; n != nil; n = n.next {
+		if last != nil && last.val == n.val {
+			continue
+		}
+		next := &node[T]{val: n.val}
+		if last == nil {
+			out.head = next
+		} else {
+			last.next = next
+		}
+		last = next
+	}
+	return out
+}
+
+// clone creates a new list with its own nodes holding the same values as l
+func (l List[T]) clone() List[T] { return Map(l, func(v T) T { return v }) }
+
+// mergeSort sorts the chain of nodes beginning at head by relinking them,
+// returning the new head of the chain.
+func mergeSort[T any](head *node[T], less func(a, b T) bool) *node[T] {
+	if head == nil || head.next == nil {
+		return head
+	}
+
+	// find the middle with a slow and a fast pointer and cut the chain there
+	slow, fast := head, head.next
+	for fast != nil && fast.next != nil {
+		slow, fast = slow.next, fast.next.next
+	}
+	right := slow.next
+	slow.next = nil
+
+	return merge(mergeSort(head, less), mergeSort(right, less), less)
+}
+
+// merge relinks two sorted chains of nodes into a single sorted chain. Ties
+// are taken from a first, which is what makes mergeSort stable.
+func merge[T any](a, b *node[T], less func(a, b T) bool) *node[T] {
+	var head node[T]
+	last := &head
+	for a != nil && b != nil {
+		if less(b.val, a.val) {
+			last.next, b = b, b.next
+		} else {
+			last.next, a = a, a.next
+		}
+		last = last.next
+	}
+	if a != nil {
+		last.next = a
+	} else {
+		last.next = b
+	}
+	return head.next
+}
@@ -0,0 +1,55 @@
+package list
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	l := Make(5, 3, 9, 1, 3, 7)
+	sorted := Sort(l)
+	eq(t, "[1, 3, 3, 5, 7, 9]", sorted.String())
+	eq(t, "[5, 3, 9, 1, 3, 7]", l.String())
+	eq(t, true, IsSorted(sorted))
+	eq(t, false, IsSorted(l))
+
+	eq(t, true, Sort(List[int]{}).Empty())
+	eq(t, true, IsSorted(List[int]{}))
+
+	var big List[int]
+	for i := 0; i < 1000; i++ {
+		big.Push(rand.Intn(100))
+	}
+	if !IsSorted(Sort(big)) {
+		t.Errorf("sorting a large list did not produce a sorted list")
+	}
+}
+
+func TestSortByStable(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	people := Make(
+		person{"alice", 30},
+		person{"bob", 20},
+		person{"carol", 30},
+		person{"dave", 20},
+	)
+
+	sorted := SortBy(people, func(a, b person) bool { return a.age < b.age })
+	names := Map(sorted, func(p person) string { return p.name })
+	eq(t, "[bob, dave, alice, carol]", names.String())
+}
+
+func TestMergeSorted(t *testing.T) {
+	merged := MergeSorted(Make(1, 4, 6), Make(2, 3, 6, 8))
+	eq(t, "[1, 2, 3, 4, 6, 6, 8]", merged.String())
+	eq(t, 1, Min(merged))
+	eq(t, 8, Max(merged))
+}
+
+func TestDedup(t *testing.T) {
+	eq(t, "[1, 2, 3, 1]", Dedup(Make(1, 1, 2, 3, 3, 3, 1)).String())
+	eq(t, "[1, 2, 3]", Dedup(Sort(Make(3, 1, 2, 1, 3))).String())
+}
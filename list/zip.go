@@ -0,0 +1,65 @@
+package list
+
+import (
+	"github.com/jordanorelli/generic/opt"
+	"github.com/jordanorelli/generic/tuple"
+)
+
+// Zip takes two lists and joins them to create a list of pairs. It's the same
+// as the python zip function: the output is as long as the shorter of the two
+// inputs, and the pairs are in the same order as the input elements.
+func Zip[T any, Z any](left List[T], right List[Z]) List[tuple.Pair[T, Z]] {
+	return ZipWith(left, right, tuple.Of[T, Z])
+}
+
+// ZipWith walks two lists side by side, combining the elements at each
+// position with the function f. Like Zip, it stops at the end of the shorter
+// list.
+func ZipWith[T any, Z any, R any](left List[T], right List[Z], f func(T, Z) R) List[R] {
+	var out List[R]
+	var last *node[R]
+	for l, r := left.head, right.head; l != nil && r != nil; l, r = l.next, r.next {
+		n := &node[R]{val: f(l.val, r.val)}
+		if last == nil {
+			out.head = n
+		} else {
+			last.next = n
+		}
+		last = n
+	}
+	return out
+}
+
+// ZipLongest is like Zip, but continues until both lists are exhausted. Once
+// the shorter list runs out, its side of each pair is None.
+func ZipLongest[T any, Z any](left List[T], right List[Z]) List[tuple.Pair[opt.Val[T], opt.Val[Z]]] {
+	var out List[tuple.Pair[opt.Val[T], opt.Val[Z]]]
+	var last *node[tuple.Pair[opt.Val[T], opt.Val[Z]]]
+	for l, r := left.head, right.head; l != nil || r != nil; {
+		var p tuple.Pair[opt.Val[T], opt.Val[Z]]
+		if l != nil {
+			p.Left = opt.Some(l.val)
+			l = l.next
+		}
+		if r != nil {
+			p.Right = opt.Some(r.val)
+			r = r.next
+		}
+
+		n := &node[tuple.Pair[opt.Val[T], opt.Val[Z]]]{val: p}
+		if last == nil {
+			out.head = n
+		} else {
+			last.next = n
+		}
+		last = n
+	}
+	return out
+}
+
+// Unzip is the inverse of Zip: it splits a list of pairs into a list of the
+// left values and a list of the right values.
+func Unzip[T any, Z any](l List[tuple.Pair[T, Z]]) (List[T], List[Z]) {
+	return Map(l, func(p tuple.Pair[T, Z]) T { return p.Left }),
+		Map(l, func(p tuple.Pair[T, Z]) Z { return p.Right })
+}
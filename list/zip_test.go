@@ -0,0 +1,53 @@
+package list
+
+import (
+	"testing"
+)
+
+func TestZip(t *testing.T) {
+	names := Make("alice", "bob", "carol")
+	ages := Make(30, 40)
+
+	zipped := Zip(names, ages)
+	eq(t, "[(alice, 30), (bob, 40)]", zipped.String())
+
+	// the old element type still works
+	var first Pair[string, int]
+	if zipped.Iter().Next(&first); first.Left != "alice" || first.Right != 30 {
+		t.Errorf("unexpected first pair: %v", first)
+	}
+
+	l, r := Unzip(zipped)
+	eq(t, "[alice, bob]", l.String())
+	eq(t, "[30, 40]", r.String())
+
+	if z := Zip(names, List[int]{}); !z.Empty() {
+		t.Errorf("zipping with an empty list should be empty but saw %v", z)
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	sums := ZipWith(Make(1, 2, 3), Make(10, 20, 30, 40), func(a, b int) int { return a + b })
+	eq(t, "[11, 22, 33]", sums.String())
+}
+
+func TestZipLongest(t *testing.T) {
+	zipped := ZipLongest(Make("alice", "bob", "carol"), Make(30))
+	eq(t, 3, zipped.Len())
+
+	first := zipped.At(0)
+	if name, ok := first.Left.Open(); !ok || name != "alice" {
+		t.Errorf("expected alice on the left but saw %v", first.Left)
+	}
+	if age, ok := first.Right.Open(); !ok || age != 30 {
+		t.Errorf("expected 30 on the right but saw %v", first.Right)
+	}
+
+	last := zipped.At(2)
+	if name, ok := last.Left.Open(); !ok || name != "carol" {
+		t.Errorf("expected carol on the left but saw %v", last.Left)
+	}
+	if _, ok := last.Right.Open(); ok {
+		t.Errorf("expected none on the right but saw %v", last.Right)
+	}
+}
@@ -0,0 +1,101 @@
+package opt
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+)
+
+// IsZero is true for empty values. The encoding/json package calls IsZero
+// for struct fields tagged with omitzero, so an empty Val in such a field is
+// left out of the JSON object entirely rather than written as null.
+func (v Val[T]) IsZero() bool { return !v.ok }
+
+// MarshalJSON encodes an empty value as null and a filled value as whatever
+// its contents encode to
+func (v Val[T]) MarshalJSON() ([]byte, error) {
+	if !v.ok {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.val)
+}
+
+// UnmarshalJSON decodes null as an empty value and anything else as a filled
+// value. A field of type Val that's missing from the JSON object is left
+// untouched, so it stays empty if it was empty to begin with.
+func (v *Val[T]) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		*v = None[T]()
+		return nil
+	}
+	var x T
+	if err := json.Unmarshal(b, &x); err != nil {
+		return fmt.Errorf("unable to unmarshal optional value: %w", err)
+	}
+	*v = Some(x)
+	return nil
+}
+
+// Scan implements the database/sql Scanner interface. SQL NULL scans into an
+// empty value; anything else is converted to T in the same manner as
+// database/sql converts a column into a destination of type *T.
+func (v *Val[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return fmt.Errorf("unable to scan optional value: %w", err)
+	}
+	*v = New(n.V, n.Valid)
+	return nil
+}
+
+// Value implements the database/sql/driver Valuer interface. An empty value
+// is written as SQL NULL.
+func (v Val[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: v.val, Valid: v.ok}.Value()
+}
+
+// MarshalText encodes an empty value as empty text. Filled values are encoded
+// with their own MarshalText method if they have one, strings are written as
+// they are, and anything else is written as JSON, which for numbers and
+// booleans is the usual text form. Note that this means Some("") and None
+// share a text form; an empty string is always decoded as None.
+func (v Val[T]) MarshalText() ([]byte, error) {
+	if !v.ok {
+		return []byte{}, nil
+	}
+	switch x := any(v.val).(type) {
+	case encoding.TextMarshaler:
+		return x.MarshalText()
+	case string:
+		return []byte(x), nil
+	default:
+		return json.Marshal(x)
+	}
+}
+
+// UnmarshalText is the inverse of MarshalText
+func (v *Val[T]) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*v = None[T]()
+		return nil
+	}
+
+	var x T
+	var err error
+	switch p := any(&x).(type) {
+	case encoding.TextUnmarshaler:
+		err = p.UnmarshalText(b)
+	case *string:
+		*p = string(b)
+	default:
+		err = json.Unmarshal(b, p)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to unmarshal optional value: %w", err)
+	}
+	*v = Some(x)
+	return nil
+}
@@ -0,0 +1,129 @@
+package opt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+type user struct {
+	Name  string
+	Email Val[string]
+	Age   Val[int] `json:",omitzero"`
+}
+
+func TestJSON(t *testing.T) {
+	b, err := json.Marshal(user{Name: "alice", Age: Some(30)})
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	if string(b) != `{"Name":"alice","Email":null,"Age":30}` {
+		t.Errorf("unexpected json: %s", b)
+	}
+
+	b, err = json.Marshal(user{Name: "bob", Email: Some("bob@example.com")})
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	if string(b) != `{"Name":"bob","Email":"bob@example.com"}` {
+		t.Errorf("unexpected json: %s", b)
+	}
+
+	var u user
+	if err := json.Unmarshal([]byte(`{"Name":"carol","Email":null,"Age":40}`), &u); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if u.Email.IsSome() {
+		t.Errorf("null should unmarshal as none but saw %v", u.Email)
+	}
+	if n, ok := u.Age.Open(); !ok || n != 40 {
+		t.Errorf("wanted 40 but got %d instead", n)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Age":"forty"}`), &u); err == nil {
+		t.Errorf("expected an error unmarshaling a string into an int")
+	}
+}
+
+func TestSQL(t *testing.T) {
+	var _ sql.Scanner = new(Val[int])
+	var _ driver.Valuer = Val[int]{}
+
+	var n Val[int64]
+	if err := n.Scan(int64(3)); err != nil {
+		t.Fatalf("unexpected scan error: %v", err)
+	}
+	if x, ok := n.Open(); !ok || x != 3 {
+		t.Errorf("wanted 3 but got %d instead", x)
+	}
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("unexpected scan error: %v", err)
+	}
+	if n.IsSome() {
+		t.Errorf("NULL should scan as none")
+	}
+
+	var s Val[string]
+	if err := s.Scan([]byte("poop")); err != nil {
+		t.Fatalf("unexpected scan error: %v", err)
+	}
+	if s.Get() != "poop" {
+		t.Errorf("wanted poop but got %s instead", s.Get())
+	}
+
+	var when Val[time.Time]
+	if err := when.Scan("not a time"); err == nil {
+		t.Errorf("expected an error scanning a string into a time")
+	}
+
+	if v, err := None[string]().Value(); err != nil || v != nil {
+		t.Errorf("none should be NULL but saw %v, %v", v, err)
+	}
+	if v, err := Some(int32(3)).Value(); err != nil || v != int64(3) {
+		t.Errorf("wanted int64 3 but saw %#v, %v", v, err)
+	}
+}
+
+func TestText(t *testing.T) {
+	cases := []struct {
+		name string
+		val  interface {
+			MarshalText() ([]byte, error)
+		}
+		text string
+	}{
+		{"none", None[int](), ""},
+		{"int", Some(12), "12"},
+		{"string", Some("hello there"), "hello there"},
+		{"marshaler", Some(netip.MustParseAddr("10.0.0.1")), "10.0.0.1"},
+	}
+	for _, c := range cases {
+		b, err := c.val.MarshalText()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if string(b) != c.text {
+			t.Errorf("%s: wanted %q but got %q", c.name, c.text, b)
+		}
+	}
+
+	var n Val[int]
+	if err := n.UnmarshalText([]byte("12")); err != nil || n.Get() != 12 {
+		t.Errorf("wanted 12 but got %v, %v", n, err)
+	}
+	if err := n.UnmarshalText([]byte("12abc")); err == nil {
+		t.Errorf("expected an error unmarshaling 12abc")
+	}
+	if err := n.UnmarshalText(nil); err != nil || n.IsSome() {
+		t.Errorf("empty text should unmarshal as none")
+	}
+
+	var addr Val[netip.Addr]
+	if err := addr.UnmarshalText([]byte("10.0.0.1")); err != nil || addr.Get().String() != "10.0.0.1" {
+		t.Errorf("wanted 10.0.0.1 but got %v, %v", addr, err)
+	}
+}
@@ -0,0 +1,50 @@
+package opt
+
+import (
+	"github.com/jordanorelli/generic/iter"
+)
+
+// Iter exposes an optional value as a collection of zero or one elements
+func Iter[T any](v Val[T]) iter.Able[T] {
+	if !v.ok {
+		return iter.Slice[T](nil)
+	}
+	return iter.Slice([]T{v.val})
+}
+
+type flatIter[T any] struct {
+	src iter.Ator[Val[T]]
+}
+
+func (it flatIter[T]) Next(v *T) bool {
+	var next Val[T]
+	for it.src.Next(&next) {
+		if next.ok {
+			*v = next.val
+			return true
+		}
+	}
+	return false
+}
+
+func (it flatIter[T]) Iter() iter.Ator[T] { return flatIter[T]{src: it.src.Iter()} }
+
+// Flatten takes an iterable of optional values and gives an iterable of the
+// contents of the filled ones, skipping over the empty ones
+func Flatten[T any](src iter.Able[Val[T]]) iter.Able[T] {
+	return flatIter[T]{src: src.Iter()}
+}
+
+// Sequence turns a slice of optional values into an optional slice: if every
+// value is filled, the result is filled with their contents in order;
+// otherwise the result is empty.
+func Sequence[T any](vals []Val[T]) Val[[]T] {
+	out := make([]T, len(vals))
+	for i, v := range vals {
+		if !v.ok {
+			return None[[]T]()
+		}
+		out[i] = v.val
+	}
+	return Some(out)
+}
@@ -0,0 +1,52 @@
+package opt
+
+import (
+	"testing"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+func TestIter(t *testing.T) {
+	n := 0
+	for v, it := iter.Start(Iter(Some(3))); it.Next(&v); n++ {
+		if v != 3 {
+			t.Errorf("wanted 3 but got %d instead", v)
+		}
+	}
+	if n != 1 {
+		t.Errorf("some should iterate once but iterated %d times", n)
+	}
+
+	for v, it := iter.Start(Iter(None[int]())); it.Next(&v); {
+		t.Errorf("none should not iterate but saw %d", v)
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	vals := iter.Slice([]Val[int]{Some(1), None[int](), Some(3), None[int]()})
+
+	sum := 0
+	for v, it := iter.Start(Flatten(vals)); it.Next(&v); {
+		sum += v
+	}
+	if sum != 4 {
+		t.Errorf("wanted 4 but got %d instead", sum)
+	}
+
+	if max := iter.Max(Flatten(vals)); max != 3 {
+		t.Errorf("wanted 3 but got %d instead", max)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	all, ok := Sequence([]Val[int]{Some(1), Some(2)}).Open()
+	if !ok || len(all) != 2 || all[1] != 2 {
+		t.Errorf("wanted [1 2] but got %v", all)
+	}
+	if Sequence([]Val[int]{Some(1), None[int]()}).IsSome() {
+		t.Error("a sequence with none in it should be none")
+	}
+	if !Sequence[int](nil).IsSome() {
+		t.Error("an empty sequence should be some")
+	}
+}
@@ -1,5 +1,9 @@
 package opt
 
+import (
+	"github.com/jordanorelli/generic/tuple"
+)
+
 // Val is an optional value
 type Val[T any] struct {
 	val T
@@ -34,6 +38,106 @@ func (v Val[T]) Open() (T, bool) {
 	return v.val, v.ok
 }
 
+// IsSome is true if the optional value is filled
+func (v Val[T]) IsSome() bool { return v.ok }
+
+// IsNone is true if the optional value is empty
+func (v Val[T]) IsNone() bool { return !v.ok }
+
+// Get retrieves the contents of our optional value. If the value is empty,
+// Get returns the zero-value for the type T.
+func (v Val[T]) Get() T { return v.val }
+
+// MustGet retrieves the contents of our optional value, panicking if the
+// value is empty
+func (v Val[T]) MustGet() T {
+	if !v.ok {
+		panic("opt: MustGet called on an empty value")
+	}
+	return v.val
+}
+
+// OrElse retrieves the contents of our optional value, or def if the value is
+// empty
+func (v Val[T]) OrElse(def T) T {
+	if v.ok {
+		return v.val
+	}
+	return def
+}
+
+// OrElseFunc is the same as OrElse, but the default is only computed if it's
+// needed
+func (v Val[T]) OrElseFunc(f func() T) T {
+	if v.ok {
+		return v.val
+	}
+	return f()
+}
+
+// Or gives back v if it's filled and other otherwise. Or can be chained to
+// pick the first filled value out of several.
+func (v Val[T]) Or(other Val[T]) Val[T] {
+	if v.ok {
+		return v
+	}
+	return other
+}
+
+// Filter empties the optional value if its contents don't pass the predicate
+// f
+func (v Val[T]) Filter(f func(T) bool) Val[T] {
+	if v.ok && f(v.val) {
+		return v
+	}
+	return None[T]()
+}
+
+// Ptr gives a pointer to a copy of the contents of our optional value, or nil
+// if the value is empty
+func (v Val[T]) Ptr() *T {
+	if !v.ok {
+		return nil
+	}
+	val := v.val
+	return &val
+}
+
+// FromPtr creates an optional value that is empty if p is nil and otherwise
+// holds a copy of the value that p points to
+func FromPtr[T any](p *T) Val[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// FromMap looks up the key k in the map m, giving an empty value if the key
+// is absent
+func FromMap[K comparable, V any](m map[K]V, k K) Val[V] {
+	v, ok := m[k]
+	return New(v, ok)
+}
+
+// FlatMap feeds the contents of an optional value to a function that itself
+// produces an optional value. Unlike Bind, the result isn't wrapped a second
+// time.
+func FlatMap[X, Y any](mx Val[X], f func(X) Val[Y]) Val[Y] {
+	if x, ok := mx.Open(); ok {
+		return f(x)
+	}
+	return None[Y]()
+}
+
+// Zip pairs up two optional values. The pair is only filled if both values
+// are filled.
+func Zip[A, B any](a Val[A], b Val[B]) Val[tuple.Pair[A, B]] {
+	if a.ok && b.ok {
+		return Some(tuple.Of(a.val, b.val))
+	}
+	return None[tuple.Pair[A, B]]()
+}
+
 // Bind takes a function that doesn't understand optionals and gives you
 // another function that does
 func Bind[X, Y any](f func(X) Y) func(Val[X]) Val[Y] {
@@ -44,3 +148,14 @@ func Bind[X, Y any](f func(X) Y) func(Val[X]) Val[Y] {
 		return None[Y]()
 	}
 }
+
+// Bind2 is the same as Bind, but for functions of two parameters. The
+// function f is only called if both of its parameters are filled.
+func Bind2[X, Y, Z any](f func(X, Y) Z) func(Val[X], Val[Y]) Val[Z] {
+	return func(mx Val[X], my Val[Y]) Val[Z] {
+		if mx.ok && my.ok {
+			return Some(f(mx.val, my.val))
+		}
+		return None[Z]()
+	}
+}
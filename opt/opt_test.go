@@ -93,3 +93,132 @@ func TestNew(t *testing.T) {
 		}
 	}
 }
+
+func TestGet(t *testing.T) {
+	if !Some(3).IsSome() || Some(3).IsNone() {
+		t.Error("some should be some")
+	}
+	if None[int]().IsSome() || !None[int]().IsNone() {
+		t.Error("none should be none")
+	}
+
+	if n := Some(3).Get(); n != 3 {
+		t.Errorf("wanted 3 but got %d instead", n)
+	}
+	if n := None[int]().Get(); n != 0 {
+		t.Errorf("wanted 0 but got %d instead", n)
+	}
+	if n := Some(3).MustGet(); n != 3 {
+		t.Errorf("wanted 3 but got %d instead", n)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustGet on none should panic")
+		}
+	}()
+	None[int]().MustGet()
+}
+
+func TestOrElse(t *testing.T) {
+	if s := Some("poop").OrElse("nothing"); s != "poop" {
+		t.Errorf("wanted poop but got %s instead", s)
+	}
+	if s := None[string]().OrElse("nothing"); s != "nothing" {
+		t.Errorf("wanted nothing but got %s instead", s)
+	}
+
+	called := false
+	fallback := func() string {
+		called = true
+		return "computed"
+	}
+	if s := Some("poop").OrElseFunc(fallback); s != "poop" || called {
+		t.Errorf("fallback should not be called for some")
+	}
+	if s := None[string]().OrElseFunc(fallback); s != "computed" || !called {
+		t.Errorf("wanted computed but got %s instead", s)
+	}
+
+	first := None[int]().Or(None[int]()).Or(Some(2)).Or(Some(3))
+	if n, ok := first.Open(); !ok || n != 2 {
+		t.Errorf("wanted 2 but got %d instead", n)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	if !Some(4).Filter(even).IsSome() {
+		t.Error("4 should pass the filter")
+	}
+	if Some(3).Filter(even).IsSome() {
+		t.Error("3 should not pass the filter")
+	}
+	if None[int]().Filter(even).IsSome() {
+		t.Error("none should stay none")
+	}
+}
+
+func TestPtr(t *testing.T) {
+	if None[int]().Ptr() != nil {
+		t.Error("none should give a nil pointer")
+	}
+	p := Some(3).Ptr()
+	if p == nil || *p != 3 {
+		t.Fatalf("wanted pointer to 3 but got %v", p)
+	}
+
+	if n, ok := FromPtr(p).Open(); !ok || n != 3 {
+		t.Errorf("wanted 3 but got %d instead", n)
+	}
+	if FromPtr[int](nil).IsSome() {
+		t.Error("nil pointer should give none")
+	}
+
+	ages := map[string]int{"alice": 30}
+	if n, ok := FromMap(ages, "alice").Open(); !ok || n != 30 {
+		t.Errorf("wanted 30 but got %d instead", n)
+	}
+	if FromMap(ages, "bob").IsSome() {
+		t.Error("missing key should give none")
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	half := func(n int) Val[int] {
+		if n%2 != 0 {
+			return None[int]()
+		}
+		return Some(n / 2)
+	}
+
+	if n, ok := FlatMap(FlatMap(Some(8), half), half).Open(); !ok || n != 2 {
+		t.Errorf("wanted 2 but got %d instead", n)
+	}
+	if FlatMap(Some(3), half).IsSome() {
+		t.Error("3 should not halve")
+	}
+	if FlatMap(None[int](), half).IsSome() {
+		t.Error("none should stay none")
+	}
+}
+
+func TestZip(t *testing.T) {
+	p, ok := Zip(Some("alice"), Some(30)).Open()
+	if !ok || p.Left != "alice" || p.Right != 30 {
+		t.Errorf("wanted (alice, 30) but got %v", p)
+	}
+	if Zip(Some("alice"), None[int]()).IsSome() {
+		t.Error("zip with none should be none")
+	}
+}
+
+func TestBind2(t *testing.T) {
+	add := Bind2(func(a, b int) int { return a + b })
+	if n, ok := add(Some(1), Some(2)).Open(); !ok || n != 3 {
+		t.Errorf("wanted 3 but got %d instead", n)
+	}
+	if add(Some(1), None[int]()).IsSome() {
+		t.Error("adding none should be none")
+	}
+}
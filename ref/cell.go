@@ -0,0 +1,79 @@
+package ref
+
+import (
+	"sync/atomic"
+)
+
+// Cell is a writable reference to a value of type T that is safe for
+// concurrent use. Every write stores a pointer to a new copy of the value and
+// every read loads whichever pointer is current, so readers never block and
+// never see a value that is halfway through being written. The zero value is
+// a cell holding the zero value of T.
+//
+// Since the value is copied on every write, a Cell is best suited to values
+// that are read often and written rarely, such as configuration. If T
+// contains pointers, maps or slices, the memory they refer to is shared
+// between the copies, and mutating it is not protected by the cell.
+type Cell[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// NewCell creates a cell holding v
+func NewCell[T any](v T) *Cell[T] {
+	var c Cell[T]
+	c.Set(v)
+	return &c
+}
+
+// load gets the current pointer's value, treating a nil pointer as the zero
+// value of T
+func load[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// Get reads the current value of the cell
+func (c *Cell[T]) Get() T { return load(c.p.Load()) }
+
+// Set writes a new value to the cell
+func (c *Cell[T]) Set(v T) { c.p.Store(&v) }
+
+// Swap writes a new value to the cell and gives back the value it replaced
+func (c *Cell[T]) Swap(v T) T { return load(c.p.Swap(&v)) }
+
+// Update replaces the value of the cell with f applied to its current value,
+// and gives back the new value. Update never blocks; if another write lands
+// between reading the current value and writing the new one, f is called
+// again with the newer value, so f may be called more than once and should
+// not have side effects.
+func (c *Cell[T]) Update(f func(T) T) T {
+	for {
+		old := c.p.Load()
+		v := f(load(old))
+		if c.p.CompareAndSwap(old, &v) {
+			return v
+		}
+	}
+}
+
+// Ref gives a read-only reference to the cell. Every read through the
+// reference sees the value of the cell at the time of reading.
+func (c *Cell[T]) Ref() Ref[T] { return readBy(c.Get) }
+
+// CompareAndSwap writes new to the cell if and only if the cell currently
+// holds old, reporting whether the write happened. It's a function rather
+// than a method because it requires T to be comparable and a Cell does not.
+func CompareAndSwap[T comparable](c *Cell[T], old, new T) bool {
+	for {
+		p := c.p.Load()
+		if load(p) != old {
+			return false
+		}
+		if c.p.CompareAndSwap(p, &new) {
+			return true
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package ref
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCell(t *testing.T) {
+	var c Cell[string]
+	if v := c.Get(); v != "" {
+		t.Errorf("zero cell should hold the zero value but holds %q", v)
+	}
+
+	r := c.Ref()
+	c.Set("alice")
+	if v := r.Val(); v != "alice" {
+		t.Errorf("ref should see the write but saw %q", v)
+	}
+
+	if old := c.Swap("bob"); old != "alice" {
+		t.Errorf("swap should give back alice but gave %q", old)
+	}
+
+	if CompareAndSwap(&c, "alice", "carol") {
+		t.Errorf("compare and swap should fail when the old value doesn't match")
+	}
+	if !CompareAndSwap(&c, "bob", "carol") {
+		t.Errorf("compare and swap should succeed when the old value matches")
+	}
+	if v := r.Val(); v != "carol" {
+		t.Errorf("expected carol but saw %q", v)
+	}
+	if s := r.String(); s != "ref{carol}" {
+		t.Errorf("unexpected string: %s", s)
+	}
+}
+
+func TestCellUpdate(t *testing.T) {
+	c := NewCell(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				c.Update(func(n int) int { return n + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := c.Get(); n != 8000 {
+		t.Errorf("expected 8000 updates but saw %d", n)
+	}
+}
+
+func TestNew(t *testing.T) {
+	name := "Jordan"
+	r := New(&name)
+	name = "Jordan Orelli"
+	if v := r.Val(); v != "Jordan Orelli" {
+		t.Errorf("ref should dereference at read time but saw %q", v)
+	}
+
+	if v := New[int](nil).Val(); v != 0 {
+		t.Errorf("nil ref should read as zero but saw %d", v)
+	}
+
+	// refs are comparable and can be used as map keys
+	seen := map[Ref[string]]bool{r: true}
+	if !seen[New(&name)] {
+		t.Errorf("refs to the same pointer should be equal")
+	}
+	other := name
+	if r == New(&other) {
+		t.Errorf("refs to different pointers should not be equal")
+	}
+
+	c := NewCell(1)
+	cr := c.Ref()
+	if cr != cr || cr == c.Ref() {
+		t.Errorf("a derived ref should only equal its own copies")
+	}
+}
@@ -0,0 +1,120 @@
+package ref
+
+import (
+	"sync"
+
+	"github.com/jordanorelli/generic/tuple"
+)
+
+// Writable is a reference that can be both read and written. Cell,
+// Observable and Versioned are all Writable, as is anything produced by
+// Focus.
+type Writable[T any] interface {
+	Get() T
+	Set(T)
+	Update(func(T) T) T
+	Ref() Ref[T]
+}
+
+// Map derives a reference from r by applying f to its value. Like every
+// Ref, the derived reference is late-binding: f is applied to the current
+// value of r every time the derived reference is read.
+func Map[A, B any](r Ref[A], f func(A) B) Ref[B] {
+	return readBy(func() B { return f(r.Val()) })
+}
+
+// Combine joins two references into a reference to a pair of their values
+func Combine[A, B any](a Ref[A], b Ref[B]) Ref[tuple.Pair[A, B]] {
+	return readBy(func() tuple.Pair[A, B] {
+		return tuple.Of(a.Val(), b.Val())
+	})
+}
+
+// Memo is like Map, but the value is derived from an observable and f is
+// only applied again once the observable has changed. Memo is for functions
+// that are expensive relative to how often the observable is written.
+func Memo[A, B any](o *Observable[A], f func(A) B) Ref[B] {
+	var (
+		mu      sync.Mutex
+		valid   bool
+		version uint64
+		cached  B
+	)
+	return readBy(func() B {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// The version is read before the value, so the value is at least as
+		// new as the version. If a write sneaks in between the two, the
+		// cache holds a newer value under an older version, which costs a
+		// recomputation on the next read but is never stale.
+		v := o.version.Load()
+		if !valid || v != version {
+			cached, version, valid = f(o.Get()), v, true
+		}
+		return cached
+	})
+}
+
+// Lens focuses on a part B of a whole A: it knows how to get the part out of
+// the whole and how to produce a new whole with a different part.
+type Lens[A, B any] struct {
+	Get func(A) B
+	Set func(A, B) A
+}
+
+// Field creates a lens for a field of a struct out of a function that points
+// at the field, e.g.:
+//
+//     port := ref.Field(func(c *Config) *int { return &c.Port })
+//
+// Setting a field through the lens copies the struct and modifies the copy,
+// it never modifies the struct it was given.
+func Field[A, B any](field func(*A) *B) Lens[A, B] {
+	return Lens[A, B]{
+		Get: func(a A) B { return *field(&a) },
+		Set: func(a A, b B) A {
+			*field(&a) = b
+			return a
+		},
+	}
+}
+
+// Compose joins two lenses so that the resulting lens focuses on the part C
+// of the part B of a whole A
+func Compose[A, B, C any](outer Lens[A, B], inner Lens[B, C]) Lens[A, C] {
+	return Lens[A, C]{
+		Get: func(a A) C { return inner.Get(outer.Get(a)) },
+		Set: func(a A, c C) A { return outer.Set(a, inner.Set(outer.Get(a), c)) },
+	}
+}
+
+// View applies the lens to a read-only reference
+func View[A, B any](r Ref[A], l Lens[A, B]) Ref[B] { return Map(r, l.Get) }
+
+// Focus applies the lens to a writable reference, giving a writable
+// reference to the part. Writes to the part are made with the source's
+// Update, so they're as atomic as any other write to the source: writing a
+// field of a Cell never loses a concurrent write to a different field, and
+// writing a field of an Observable notifies its subscribers.
+func Focus[A, B any](w Writable[A], l Lens[A, B]) Writable[B] {
+	return focus[A, B]{src: w, lens: l}
+}
+
+type focus[A, B any] struct {
+	src  Writable[A]
+	lens Lens[A, B]
+}
+
+func (f focus[A, B]) Get() B { return f.lens.Get(f.src.Get()) }
+
+func (f focus[A, B]) Set(b B) {
+	f.src.Update(func(a A) A { return f.lens.Set(a, b) })
+}
+
+func (f focus[A, B]) Update(fn func(B) B) B {
+	a := f.src.Update(func(a A) A { return f.lens.Set(a, fn(f.lens.Get(a))) })
+	return f.lens.Get(a)
+}
+
+func (f focus[A, B]) Ref() Ref[B] { return readBy(f.Get) }
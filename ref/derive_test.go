@@ -0,0 +1,102 @@
+package ref
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+type limits struct {
+	Max int
+}
+
+type config struct {
+	Host   string
+	Port   int
+	Limits limits
+}
+
+func TestMap(t *testing.T) {
+	name := "jordan"
+	upper := Map(New(&name), strings.ToUpper)
+	name = "orelli"
+	if v := upper.Val(); v != "ORELLI" {
+		t.Errorf("expected ORELLI but saw %q", v)
+	}
+
+	port := 80
+	both := Combine(New(&name), New(&port))
+	port = 8080
+	if p := both.Val(); p.Left != "orelli" || p.Right != 8080 {
+		t.Errorf("unexpected combined value: %v", p)
+	}
+}
+
+func TestMemo(t *testing.T) {
+	o := NewObservable(2, nil)
+	calls := 0
+	square := Memo(o, func(n int) int {
+		calls++
+		return n * n
+	})
+
+	if square.Val() != 4 || square.Val() != 4 || calls != 1 {
+		t.Errorf("expected one computation of 4 but saw %d calls", calls)
+	}
+	o.Set(3)
+	if v := square.Val(); v != 9 || calls != 2 {
+		t.Errorf("expected recomputation to 9 but saw %d after %d calls", v, calls)
+	}
+}
+
+func TestField(t *testing.T) {
+	port := Field(func(c *config) *int { return &c.Port })
+	max := Compose(
+		Field(func(c *config) *limits { return &c.Limits }),
+		Field(func(l *limits) *int { return &l.Max }),
+	)
+
+	orig := config{Host: "localhost", Port: 80}
+	moved := port.Set(orig, 8080)
+	if orig.Port != 80 || moved.Port != 8080 || moved.Host != "localhost" {
+		t.Errorf("setting through a lens should copy: %v, %v", orig, moved)
+	}
+
+	c := NewCell(orig)
+	portRef := View(c.Ref(), port)
+	Focus[config, int](c, port).Set(9090)
+	if v := portRef.Val(); v != 9090 {
+		t.Errorf("expected 9090 but saw %d", v)
+	}
+
+	// concurrent writes to different fields through lenses don't clobber
+	// one another
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 250; j++ {
+				Focus[config, int](c, port).Update(func(n int) int { return n + 1 })
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 250; j++ {
+				Focus[config, int](c, max).Update(func(n int) int { return n + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+	if got := c.Get(); got.Port != 10090 || got.Limits.Max != 1000 {
+		t.Errorf("unexpected config after concurrent updates: %+v", got)
+	}
+
+	o := NewObservable(orig, nil)
+	notified := 0
+	o.Subscribe(func(old, new config) { notified++ })
+	Focus[config, int](o, max).Set(5)
+	if notified != 1 || o.Get().Limits.Max != 5 {
+		t.Errorf("writing a field of an observable should notify")
+	}
+}
@@ -0,0 +1,102 @@
+package ref
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy is a value that is computed the first time it's read. Unlike creating
+// a Ref with New(nil), which quietly refers to a zero value, a Lazy makes it
+// explicit that the value doesn't exist until somebody needs it. Lazy is safe
+// for concurrent use: the initializer runs exactly once, and every reader
+// waits for it to finish.
+type Lazy[T any] struct {
+	val func() T
+}
+
+// NewLazy creates a lazy value that is computed by init
+func NewLazy[T any](init func() T) *Lazy[T] { return &Lazy[T]{val: sync.OnceValue(init)} }
+
+// Val reads the value, computing it first if this is the first read. If init
+// panics, every read panics with the same value, the same as with
+// sync.OnceValue; a broken initializer never quietly turns into a zero value.
+func (l *Lazy[T]) Val() T { return l.val() }
+
+// Ref gives a reference to the lazy value, which computes the value upon the
+// first read through either the reference or the Lazy itself
+func (l *Lazy[T]) Ref() Ref[T] { return readBy(l.Val) }
+
+// LazyErr is a lazy value whose initializer can fail. Depending on how it was
+// created, a failure is either remembered forever or forgotten so that the
+// next read tries again.
+type LazyErr[T any] struct {
+	done  atomic.Bool
+	mu    sync.Mutex
+	retry bool
+	init  func() (T, error)
+	val   T
+	err   error
+
+	// panicked is true if init panicked, with p being the value it panicked
+	// with
+	panicked bool
+	p        any
+}
+
+// NewLazyErr creates a lazy value that is computed by init. Whatever init
+// gives back the first time, be it a value or an error, is what every read
+// gives back. If init panics, every read panics with the same value, the same
+// as with Lazy.
+func NewLazyErr[T any](init func() (T, error)) *LazyErr[T] {
+	return &LazyErr[T]{init: init}
+}
+
+// NewLazyRetry creates a lazy value that is computed by init. If init fails,
+// the read that called it gets the error but nothing is remembered, and the
+// next read calls init again. A panic in init counts as a failure: it's
+// passed along to the read that called init and the next read tries again.
+// Once init succeeds, its value is what every later read gives back.
+func NewLazyRetry[T any](init func() (T, error)) *LazyErr[T] {
+	return &LazyErr[T]{init: init, retry: true}
+}
+
+// Val reads the value, computing it first if necessary. Only one call to
+// init is ever in flight at a time; concurrent readers wait for it.
+func (l *LazyErr[T]) Val() (T, error) {
+	if l.done.Load() {
+		return l.result()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.done.Load() {
+		return l.result()
+	}
+
+	ok := false
+	defer func() {
+		if !ok && !l.retry {
+			l.p, l.panicked, l.init = recover(), true, nil
+			l.done.Store(true)
+			panic(l.p)
+		}
+	}()
+	v, err := l.init()
+	ok = true
+
+	if err != nil && l.retry {
+		var zero T
+		return zero, err
+	}
+	l.val, l.err, l.init = v, err, nil
+	l.done.Store(true)
+	return v, err
+}
+
+// result gives back whatever the one call to init produced
+func (l *LazyErr[T]) result() (T, error) {
+	if l.panicked {
+		panic(l.p)
+	}
+	return l.val, l.err
+}
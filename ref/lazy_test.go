@@ -0,0 +1,163 @@
+package ref
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	var calls int32
+	l := NewLazy(func() int {
+		atomic.AddInt32(&calls, 1)
+		return 42
+	})
+	r := l.Ref()
+	if calls != 0 {
+		t.Fatalf("lazy value should not be computed before it's read")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v := r.Val(); v != 42 {
+				t.Errorf("expected 42 but saw %d", v)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected the initializer to run once but it ran %d times", calls)
+	}
+}
+
+func TestLazyPanic(t *testing.T) {
+	calls := 0
+	l := NewLazy(func() int {
+		calls++
+		panic("broken")
+	})
+	for i := 0; i < 3; i++ {
+		func() {
+			defer func() {
+				if r := recover(); r != "broken" {
+					t.Errorf("expected read %d to panic with broken but saw %v", i, r)
+				}
+			}()
+			l.Val()
+		}()
+	}
+	if calls != 1 {
+		t.Errorf("expected the initializer to run once but it ran %d times", calls)
+	}
+}
+
+func TestLazyErrPanic(t *testing.T) {
+	calls := 0
+	init := func() (int, error) {
+		calls++
+		panic("broken")
+	}
+
+	read := func(l *LazyErr[int]) (r any) {
+		defer func() { r = recover() }()
+		l.Val()
+		return nil
+	}
+
+	l := NewLazyErr(init)
+	for i := 0; i < 3; i++ {
+		if r := read(l); r != "broken" {
+			t.Errorf("expected read %d to panic with broken but saw %v", i, r)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected the initializer to run once but it ran %d times", calls)
+	}
+
+	calls = 0
+	retry := NewLazyRetry(init)
+	for i := 0; i < 3; i++ {
+		if r := read(retry); r != "broken" {
+			t.Errorf("expected read %d to panic with broken but saw %v", i, r)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("expected every read to retry the initializer but saw %d calls", calls)
+	}
+}
+
+var errFlaky = errors.New("flaky")
+
+func flaky(failures int) (func() (string, error), *int) {
+	calls := 0
+	return func() (string, error) {
+		calls++
+		if calls <= failures {
+			return "", errFlaky
+		}
+		return "ready", nil
+	}, &calls
+}
+
+func TestLazyErr(t *testing.T) {
+	init, calls := flaky(1)
+	l := NewLazyErr(init)
+	for i := 0; i < 3; i++ {
+		if _, err := l.Val(); !errors.Is(err, errFlaky) {
+			t.Errorf("expected the first failure to be remembered but saw %v", err)
+		}
+	}
+	if *calls != 1 {
+		t.Errorf("expected one call but saw %d", *calls)
+	}
+}
+
+func TestLazyRetry(t *testing.T) {
+	init, calls := flaky(2)
+	l := NewLazyRetry(init)
+	for i := 0; i < 2; i++ {
+		if _, err := l.Val(); !errors.Is(err, errFlaky) {
+			t.Errorf("expected failure %d but saw %v", i, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if v, err := l.Val(); err != nil || v != "ready" {
+			t.Errorf("expected ready but saw %q, %v", v, err)
+		}
+	}
+	if *calls != 3 {
+		t.Errorf("expected 3 calls but saw %d", *calls)
+	}
+}
+
+func TestWeak(t *testing.T) {
+	type blob struct{ data [1 << 10]byte }
+
+	b := &blob{}
+	b.data[0] = 7
+	w := NewWeak(b)
+	if v, ok := w.Val(); !ok || v.data[0] != 7 {
+		t.Fatalf("expected the value to be alive")
+	}
+	runtime.KeepAlive(b)
+
+	b = nil
+	for i := 0; i < 5; i++ {
+		runtime.GC()
+		if w.Strong() == nil {
+			break
+		}
+	}
+	if _, ok := w.Val(); ok {
+		t.Errorf("expected the value to be collected")
+	}
+
+	if _, ok := NewWeak[int](nil).Val(); ok {
+		t.Errorf("a weak reference to nil should have no value")
+	}
+}
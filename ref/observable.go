@@ -0,0 +1,158 @@
+package ref
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Observable is a writable reference that tells its subscribers about every
+// change to its value. Reads are lock-free, exactly as they are for a Cell.
+// Writes are serialized, and subscribers are called synchronously by the
+// writing goroutine, in the order the writes happened. Since a write isn't
+// finished until every subscriber has returned, a subscriber must not write
+// to the observable that called it. Subscribers may subscribe, unsubscribe
+// (themselves included) and watch, though a subscriber that's removed while a
+// write is notifying may still be called for that one write. The zero value
+// is an observable holding the zero value of T, with no equality function.
+type Observable[T any] struct {
+	cell    Cell[T]
+	equal   func(a, b T) bool
+	version atomic.Uint64
+
+	// write serializes writes along with their notifications; mu guards the
+	// subscribers and is never held while calling them. When both are held,
+	// write is taken first.
+	write sync.Mutex
+	mu    sync.Mutex
+	subs  map[int]func(old, new T)
+	next  int
+}
+
+// NewObservable creates an observable holding v. If equal is not nil, writes
+// of a value that is equal to the current value are ignored entirely, so
+// that no-op writes don't notify anybody. For comparable types, Equal can be
+// used as the equality function.
+func NewObservable[T any](v T, equal func(a, b T) bool) *Observable[T] {
+	o := &Observable[T]{equal: equal}
+	o.cell.Set(v)
+	return o
+}
+
+// Equal compares two values of a comparable type with ==
+func Equal[T comparable](a, b T) bool { return a == b }
+
+// Get reads the current value
+func (o *Observable[T]) Get() T { return o.cell.Get() }
+
+// Ref gives a read-only reference to the observable
+func (o *Observable[T]) Ref() Ref[T] { return o.cell.Ref() }
+
+// Set writes a new value and notifies every subscriber
+func (o *Observable[T]) Set(v T) {
+	o.Update(func(T) T { return v })
+}
+
+// Update replaces the value with f applied to the current value and notifies
+// every subscriber. Unlike Cell.Update, f is only ever called once, since
+// writes to an observable are serialized.
+func (o *Observable[T]) Update(f func(T) T) T {
+	o.write.Lock()
+	defer o.write.Unlock()
+
+	old := o.cell.Get()
+	v := f(old)
+	if o.equal != nil && o.equal(old, v) {
+		return old
+	}
+	o.cell.Set(v)
+	o.version.Add(1)
+
+	o.mu.Lock()
+	subs := make([]func(old, new T), 0, len(o.subs))
+	for _, fn := range o.subs {
+		subs = append(subs, fn)
+	}
+	o.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(old, v)
+	}
+	return v
+}
+
+// Subscribe registers fn to be called with the old and new value after every
+// write. Subscribe gives back a function that cancels the subscription; it's
+// safe to call more than once.
+func (o *Observable[T]) Subscribe(fn func(old, new T)) (unsubscribe func()) {
+	o.mu.Lock()
+	id := o.add(fn)
+	o.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			o.mu.Lock()
+			delete(o.subs, id)
+			o.mu.Unlock()
+		})
+	}
+}
+
+// add registers a subscriber, giving back its id. The caller must hold o.mu.
+func (o *Observable[T]) add(fn func(old, new T)) int {
+	if o.subs == nil {
+		o.subs = make(map[int]func(old, new T))
+	}
+	id := o.next
+	o.next++
+	o.subs[id] = fn
+	return id
+}
+
+// Watch gives a channel that receives the current value right away and then
+// the latest value after each write. Values are coalesced: a slow receiver
+// doesn't hold up writers, it just misses intermediate values and sees the
+// most recent one. The channel is closed once ctx is done.
+func (o *Observable[T]) Watch(ctx context.Context) <-chan T {
+	c := make(chan T, 1)
+
+	// send is only ever called with cmu held, so it's the only sender; after
+	// dropping a stale value, there's always room in the buffer. A write that
+	// was already notifying when the watch ended may still call send after
+	// the channel is closed, which is what closed is for.
+	var cmu sync.Mutex
+	var closed bool
+	send := func(v T) {
+		cmu.Lock()
+		defer cmu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case <-c:
+		default:
+		}
+		c <- v
+	}
+
+	// the current value is sent with o.mu held so that it's in the channel
+	// before any write can notify the new subscriber
+	o.mu.Lock()
+	send(o.cell.Get())
+	id := o.add(func(_, v T) { send(v) })
+	o.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		o.mu.Lock()
+		delete(o.subs, id)
+		o.mu.Unlock()
+
+		cmu.Lock()
+		closed = true
+		close(c)
+		cmu.Unlock()
+	}()
+	return c
+}
@@ -0,0 +1,109 @@
+package ref
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestObservable(t *testing.T) {
+	o := NewObservable("alice", nil)
+
+	var changes []string
+	unsubscribe := o.Subscribe(func(old, new string) {
+		changes = append(changes, old+"->"+new)
+	})
+
+	o.Set("bob")
+	o.Set("bob")
+	o.Update(func(s string) string { return s + "!" })
+	unsubscribe()
+	unsubscribe()
+	o.Set("carol")
+
+	if len(changes) != 3 || changes[0] != "alice->bob" || changes[2] != "bob->bob!" {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+	if v := o.Ref().Val(); v != "carol" {
+		t.Errorf("expected carol but saw %q", v)
+	}
+}
+
+func TestObservableEqual(t *testing.T) {
+	o := NewObservable(1, Equal[int])
+
+	calls := 0
+	o.Subscribe(func(old, new int) { calls++ })
+	o.Set(1)
+	o.Set(2)
+	o.Set(2)
+	if calls != 1 {
+		t.Errorf("expected equal writes to be suppressed but saw %d calls", calls)
+	}
+}
+
+func TestObservableReentrant(t *testing.T) {
+	o := NewObservable(0, nil)
+
+	// handle the first change only
+	once := 0
+	var unsubscribe func()
+	unsubscribe = o.Subscribe(func(old, new int) {
+		once++
+		unsubscribe()
+	})
+
+	// subscribing and watching from inside a subscriber
+	late := 0
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var watched <-chan int
+	o.Subscribe(func(old, new int) {
+		if new == 1 {
+			o.Subscribe(func(old, new int) { late++ })
+			watched = o.Watch(ctx)
+		}
+	})
+
+	o.Set(1)
+	o.Set(2)
+	o.Set(3)
+	if once != 1 {
+		t.Errorf("expected the self-removing subscriber to be called once but saw %d calls", once)
+	}
+	if late != 2 {
+		t.Errorf("expected the late subscriber to see 2 writes but saw %d", late)
+	}
+	if v := <-watched; v != 3 {
+		t.Errorf("expected the latest value but saw %d", v)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	o := NewObservable(0, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	c := o.Watch(ctx)
+
+	if v := <-c; v != 0 {
+		t.Errorf("expected the current value first but saw %d", v)
+	}
+
+	// nobody is receiving, so these coalesce into the latest value
+	for i := 1; i <= 10; i++ {
+		o.Set(i)
+	}
+	if v := <-c; v != 10 {
+		t.Errorf("expected the latest value but saw %d", v)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-c:
+		if ok {
+			t.Errorf("expected the channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("watch channel was not closed after cancellation")
+	}
+	o.Set(11)
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 )
 
-// New creates a reference for a given pointer
+// New creates a reference for a given pointer. If v is nil, the reference
+// refers to a zero value of its own; to defer creating a value until it's
+// needed, use a Lazy instead.
 func New[T any](v *T) Ref[T] {
 	if v == nil {
 		var zero T
@@ -13,10 +15,29 @@ func New[T any](v *T) Ref[T] {
 	return Ref[T]{ptr: v}
 }
 
-// Ref is a read reference to some value T
-type Ref[T any] struct { ptr *T }
+// Ref is a read reference to some value T. A Ref doesn't hold a value
+// itself; it knows how to read one, which happens every time Val is called.
+// Refs are comparable: two refs made by New are equal if they were made from
+// the same pointer, and refs made any other way are only equal to copies of
+// themselves.
+type Ref[T any] struct {
+	ptr *T
+
+	// read is how refs that aren't backed by a plain pointer, such as those
+	// given by Cell.Ref and Map, read their value. It's a pointer so that Ref
+	// stays comparable.
+	read *func() T
+}
+
+// readBy creates a reference that reads its value by calling f
+func readBy[T any](f func() T) Ref[T] { return Ref[T]{read: &f} }
 
 // Val reads the value for this reference
-func (r Ref[T]) Val() T { return *r.ptr }
+func (r Ref[T]) Val() T {
+	if r.read != nil {
+		return (*r.read)()
+	}
+	return *r.ptr
+}
 
-func (r Ref[T]) String() string { return fmt.Sprintf("ref{%v}", *r.ptr) }
+func (r Ref[T]) String() string { return fmt.Sprintf("ref{%v}", r.Val()) }
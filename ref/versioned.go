@@ -0,0 +1,160 @@
+package ref
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jordanorelli/generic/opt"
+)
+
+// ErrNoVersion is returned when asking for a version that was never written
+// or that has already fallen out of the history of a Versioned
+var ErrNoVersion = errors.New("version not in history")
+
+// Snapshot is a value along with the version at which it was written
+type Snapshot[T any] struct {
+	Val     T
+	Version uint64
+}
+
+// Versioned is a writable reference that numbers its writes. The initial
+// value is version 1 and every write after that gets the next version.
+// Versioned keeps a bounded history of the most recent writes, which can be
+// inspected with At and History and restored with Rollback. Reads are
+// lock-free; writes are serialized. The zero value is the same as
+// NewVersioned of the zero value of T with a history of 1.
+type Versioned[T any] struct {
+	cur atomic.Pointer[Snapshot[T]]
+
+	mu   sync.Mutex
+	ring []Snapshot[T]
+	next int // index in ring of the next write
+	size int // number of snapshots in ring
+}
+
+// NewVersioned creates a versioned reference holding v that remembers up to
+// history versions, including the current one. A history of less than 1 is
+// treated as 1.
+func NewVersioned[T any](v T, history int) *Versioned[T] {
+	if history < 1 {
+		history = 1
+	}
+	r := &Versioned[T]{ring: make([]Snapshot[T], history)}
+	r.record(v)
+	return r
+}
+
+// init sets up the history of a zero Versioned. The caller must hold r.mu.
+func (r *Versioned[T]) init() {
+	if r.ring == nil {
+		var zero T
+		r.ring = make([]Snapshot[T], 1)
+		r.record(zero)
+	}
+}
+
+// load gets the current snapshot, which for a zero Versioned that's never
+// been written is the zero value of T at version 1
+func (r *Versioned[T]) load() Snapshot[T] {
+	if cur := r.cur.Load(); cur != nil {
+		return *cur
+	}
+	return Snapshot[T]{Version: 1}
+}
+
+// record writes v as a new version. The caller must hold r.mu, except when
+// the Versioned is being created.
+func (r *Versioned[T]) record(v T) Snapshot[T] {
+	s := Snapshot[T]{Val: v, Version: 1}
+	if cur := r.cur.Load(); cur != nil {
+		s.Version = cur.Version + 1
+	}
+
+	r.ring[r.next] = s
+	r.next = (r.next + 1) % len(r.ring)
+	if r.size < len(r.ring) {
+		r.size++
+	}
+	r.cur.Store(&s)
+	return s
+}
+
+// Snapshot reads the current value along with its version. The two always
+// belong together, even while other goroutines are writing.
+func (r *Versioned[T]) Snapshot() Snapshot[T] { return r.load() }
+
+// Get reads the current value
+func (r *Versioned[T]) Get() T { return r.load().Val }
+
+// Ref gives a read-only reference to the current value
+func (r *Versioned[T]) Ref() Ref[T] { return readBy(r.Get) }
+
+// Set writes v as a new version
+func (r *Versioned[T]) Set(v T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	r.record(v)
+}
+
+// Update writes f applied to the current value as a new version, giving back
+// the new value
+func (r *Versioned[T]) Update(f func(T) T) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	return r.record(f(r.Get())).Val
+}
+
+// At gets the value that was written at the given version, if it's still in
+// the history
+func (r *Versioned[T]) At(version uint64) opt.Val[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	if s, ok := r.lookup(version); ok {
+		return opt.Some(s.Val)
+	}
+	return opt.None[T]()
+}
+
+// lookup finds a version in the history. The caller must hold r.mu.
+func (r *Versioned[T]) lookup(version uint64) (Snapshot[T], bool) {
+	latest := r.cur.Load().Version
+	if version == 0 || version > latest || latest-version >= uint64(r.size) {
+		return Snapshot[T]{}, false
+	}
+	back := int(latest - version)
+	i := (r.next - 1 - back + len(r.ring)) % len(r.ring)
+	return r.ring[i], true
+}
+
+// History lists the versions that are still remembered, oldest first
+func (r *Versioned[T]) History() []Snapshot[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	out := make([]Snapshot[T], r.size)
+	for i := range out {
+		out[i] = r.ring[(r.next-r.size+i+len(r.ring))%len(r.ring)]
+	}
+	return out
+}
+
+// Rollback restores the value that was written at the given version. The
+// restored value is written as a new version rather than discarding the
+// versions that came after it, so versions only ever move forward and the
+// rollback itself shows up in the history. Rollback gives back the snapshot
+// of the new version.
+func (r *Versioned[T]) Rollback(version uint64) (Snapshot[T], error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.init()
+	s, ok := r.lookup(version)
+	if !ok {
+		return Snapshot[T]{}, fmt.Errorf("unable to roll back to version %d: %w", version, ErrNoVersion)
+	}
+	return r.record(s.Val), nil
+}
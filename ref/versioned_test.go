@@ -0,0 +1,80 @@
+package ref
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestVersioned(t *testing.T) {
+	r := NewVersioned("v1", 3)
+	if s := r.Snapshot(); s.Val != "v1" || s.Version != 1 {
+		t.Errorf("unexpected initial snapshot: %+v", s)
+	}
+
+	r.Set("v2")
+	r.Update(func(s string) string { return s + "+" })
+	r.Set("v4")
+
+	if s := r.Snapshot(); s.Val != "v4" || s.Version != 4 {
+		t.Errorf("unexpected snapshot: %+v", s)
+	}
+	if v, ok := r.At(3).Open(); !ok || v != "v2+" {
+		t.Errorf("expected v2+ at version 3 but saw %q, %t", v, ok)
+	}
+	if r.At(1).IsSome() {
+		t.Errorf("version 1 should have fallen out of a history of 3")
+	}
+	if r.At(5).IsSome() || r.At(0).IsSome() {
+		t.Errorf("versions that were never written should not be found")
+	}
+
+	if got := fmt.Sprint(r.History()); got != "[{v2 2} {v2+ 3} {v4 4}]" {
+		t.Errorf("unexpected history: %s", got)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	r := NewVersioned(100, 10)
+	r.Set(200)
+	r.Set(300)
+
+	s, err := r.Rollback(1)
+	if err != nil {
+		t.Fatalf("unexpected rollback error: %v", err)
+	}
+	if s.Val != 100 || s.Version != 4 {
+		t.Errorf("rollback should write a new version but gave %+v", s)
+	}
+	if v := r.Ref().Val(); v != 100 {
+		t.Errorf("expected 100 after rollback but saw %d", v)
+	}
+
+	if _, err := r.Rollback(9); !errors.Is(err, ErrNoVersion) {
+		t.Errorf("expected ErrNoVersion but saw %v", err)
+	}
+
+	var _ Writable[int] = r
+}
+
+func TestVersionedZero(t *testing.T) {
+	var r Versioned[string]
+	if s := r.Snapshot(); s.Val != "" || s.Version != 1 {
+		t.Errorf("unexpected snapshot of a zero versioned: %v", s)
+	}
+	if v, ok := r.At(1).Open(); !ok || v != "" {
+		t.Errorf("expected version 1 to hold the zero value")
+	}
+
+	r.Set("a")
+	r.Set("b")
+	if s := r.Snapshot(); s.Val != "b" || s.Version != 3 {
+		t.Errorf("unexpected snapshot after writes: %v", s)
+	}
+	if h := r.History(); len(h) != 1 || h[0].Val != "b" {
+		t.Errorf("expected a history of only the current version but saw %v", h)
+	}
+	if _, err := r.Rollback(2); !errors.Is(err, ErrNoVersion) {
+		t.Errorf("expected version 2 to be forgotten but saw %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package ref
+
+import (
+	"weak"
+)
+
+// Weak is a reference that doesn't keep its value alive. Once nothing else
+// refers to the value, the garbage collector is free to collect it, after
+// which the weak reference reports that the value is gone.
+type Weak[T any] struct {
+	ptr weak.Pointer[T]
+}
+
+// NewWeak creates a weak reference to the value that p points to. A weak
+// reference to nil reports that its value is gone from the start.
+func NewWeak[T any](p *T) Weak[T] { return Weak[T]{ptr: weak.Make(p)} }
+
+// Val reads the value if it's still around. If the value has been collected,
+// Val gives the zero value of T and false.
+func (w Weak[T]) Val() (T, bool) {
+	p := w.ptr.Value()
+	if p == nil {
+		var zero T
+		return zero, false
+	}
+	return *p, true
+}
+
+// Strong gives an ordinary pointer to the value, which keeps the value alive
+// for as long as the pointer is in use, or nil if the value has been
+// collected
+func (w Weak[T]) Strong() *T { return w.ptr.Value() }
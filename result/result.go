@@ -0,0 +1,147 @@
+// result provides a type for values that may have failed to materialize
+package result
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jordanorelli/generic/opt"
+)
+
+// Of is the result of something that can fail: either a value of type T or
+// an error. It's the same idea as opt.Val, except that the absence of a
+// value comes with an explanation.
+type Of[T any] struct {
+	val T
+	err error
+}
+
+// New creates a result out of the usual (T, error) pair that a Go function
+// returns, so that e.g. result.New(strconv.Atoi(s)) is a result
+func New[T any](v T, err error) Of[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
+// Ok creates a successful result
+func Ok[T any](v T) Of[T] { return Of[T]{val: v} }
+
+// Err creates a failed result. Err panics if err is nil, since a result
+// can't fail without a reason.
+func Err[T any](err error) Of[T] {
+	if err == nil {
+		panic("result: Err called with a nil error")
+	}
+	return Of[T]{err: err}
+}
+
+// Open retrieves the contents of the result as a (T, error) pair
+func (r Of[T]) Open() (T, error) { return r.val, r.err }
+
+// IsOk is true for successful results
+func (r Of[T]) IsOk() bool { return r.err == nil }
+
+// IsErr is true for failed results
+func (r Of[T]) IsErr() bool { return r.err != nil }
+
+// Err gets the error of a failed result, or nil for a successful result
+func (r Of[T]) Err() error { return r.err }
+
+// Unwrap gets the value of a successful result. Unwrap panics if the result
+// failed; use it only when failure would be a programming error.
+func (r Of[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("result: Unwrap called on a failed result: %v", r.err))
+	}
+	return r.val
+}
+
+// UnwrapOr gets the value of a successful result, or def if the result
+// failed
+func (r Of[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.val
+}
+
+// OrElse gives a failed result a chance to recover: if r failed, OrElse
+// returns whatever f makes of its error. A successful result is returned as
+// it is.
+func (r Of[T]) OrElse(f func(error) Of[T]) Of[T] {
+	if r.err != nil {
+		return f(r.err)
+	}
+	return r
+}
+
+// Is reports whether the error of the result matches target, in the same
+// manner as errors.Is. A successful result matches nothing.
+func (r Of[T]) Is(target error) bool { return r.err != nil && errors.Is(r.err, target) }
+
+// As finds the first error in the chain of the result's error that matches
+// target, in the same manner as errors.As
+func (r Of[T]) As(target any) bool { return r.err != nil && errors.As(r.err, target) }
+
+// Opt converts the result to an optional value, dropping the error
+func (r Of[T]) Opt() opt.Val[T] { return opt.New(r.val, r.err == nil) }
+
+// FromOpt converts an optional value to a result, using err as the error if
+// the optional value is empty
+func FromOpt[T any](v opt.Val[T], err error) Of[T] {
+	if x, ok := v.Open(); ok {
+		return Ok(x)
+	}
+	return Err[T](err)
+}
+
+func (r Of[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("err{%v}", r.err)
+	}
+	return fmt.Sprintf("ok{%v}", r.val)
+}
+
+// Map applies f to the value of a successful result. A failed result passes
+// through untouched.
+func Map[T, Z any](r Of[T], f func(T) Z) Of[Z] {
+	if r.err != nil {
+		return Of[Z]{err: r.err}
+	}
+	return Ok(f(r.val))
+}
+
+// AndThen chains a step that can fail onto a result: if r succeeded, its
+// value is fed to f, otherwise the failure passes through and f is never
+// called.
+func AndThen[T, Z any](r Of[T], f func(T) Of[Z]) Of[Z] {
+	if r.err != nil {
+		return Of[Z]{err: r.err}
+	}
+	return f(r.val)
+}
+
+// Bind takes a function in the usual Go style of returning (Y, error) and
+// gives you another function that works on results, in the same manner as
+// opt.Bind
+func Bind[X, Y any](f func(X) (Y, error)) func(Of[X]) Of[Y] {
+	return func(r Of[X]) Of[Y] {
+		return AndThen(r, func(x X) Of[Y] { return New(f(x)) })
+	}
+}
+
+// Collect turns a slice of results into a result of a slice: if every result
+// succeeded, the values are collected in order; otherwise the error of the
+// first failed result is returned.
+func Collect[T any](results []Of[T]) Of[[]T] {
+	vals := make([]T, len(results))
+	for i, r := range results {
+		if r.err != nil {
+			return Of[[]T]{err: r.err}
+		}
+		vals[i] = r.val
+	}
+	return Ok(vals)
+}
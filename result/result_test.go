@@ -0,0 +1,111 @@
+package result
+
+import (
+	"errors"
+	"io/fs"
+	"strconv"
+	"testing"
+
+	"github.com/jordanorelli/generic/opt"
+)
+
+var errNegative = errors.New("negative")
+
+func positive(n int) Of[int] {
+	if n < 0 {
+		return Err[int](errNegative)
+	}
+	return Ok(n)
+}
+
+func TestNew(t *testing.T) {
+	r := New(strconv.Atoi("12"))
+	if !r.IsOk() || r.Unwrap() != 12 {
+		t.Errorf("expected ok{12} but saw %v", r)
+	}
+
+	r = New(strconv.Atoi("twelve"))
+	if !r.IsErr() {
+		t.Fatalf("expected an error but saw %v", r)
+	}
+	if v := r.UnwrapOr(-1); v != -1 {
+		t.Errorf("expected default of -1 but saw %d", v)
+	}
+
+	var numErr *strconv.NumError
+	if !r.As(&numErr) || numErr.Func != "Atoi" {
+		t.Errorf("expected a *strconv.NumError but saw %v", r.Err())
+	}
+	if !r.Is(strconv.ErrSyntax) {
+		t.Errorf("expected result to match strconv.ErrSyntax")
+	}
+	if Ok(3).Is(strconv.ErrSyntax) {
+		t.Errorf("a successful result should not match any error")
+	}
+}
+
+func TestUnwrapPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Unwrap of a failed result to panic")
+		}
+	}()
+	Err[int](errNegative).Unwrap()
+}
+
+func TestChain(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	atoi := Bind(strconv.Atoi)
+
+	r := AndThen(Map(atoi(Ok("21")), double), positive)
+	if v, err := r.Open(); err != nil || v != 42 {
+		t.Errorf("expected 42 but saw %v", r)
+	}
+
+	r = AndThen(Map(atoi(Ok("-21")), double), positive)
+	if !r.Is(errNegative) {
+		t.Errorf("expected negative error but saw %v", r)
+	}
+
+	r = AndThen(atoi(Ok("x")), func(n int) Of[int] {
+		t.Errorf("AndThen should not be called on a failed result")
+		return Ok(n)
+	})
+	if !r.Is(strconv.ErrSyntax) {
+		t.Errorf("expected the parse error to pass through but saw %v", r)
+	}
+
+	recovered := r.OrElse(func(err error) Of[int] { return Ok(0) })
+	if recovered.Unwrap() != 0 {
+		t.Errorf("expected OrElse to recover to 0 but saw %v", recovered)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	all := Collect([]Of[int]{Ok(1), Ok(2), Ok(3)})
+	if vals := all.Unwrap(); len(vals) != 3 || vals[2] != 3 {
+		t.Errorf("unexpected collected values: %v", vals)
+	}
+
+	some := Collect([]Of[int]{Ok(1), positive(-2), Err[int](fs.ErrNotExist)})
+	if !some.Is(errNegative) {
+		t.Errorf("expected the first error but saw %v", some)
+	}
+}
+
+func TestOpt(t *testing.T) {
+	if v, ok := Ok(3).Opt().Open(); !ok || v != 3 {
+		t.Errorf("expected some 3 but saw %v, %t", v, ok)
+	}
+	if _, ok := Err[int](errNegative).Opt().Open(); ok {
+		t.Errorf("expected a failed result to convert to none")
+	}
+
+	r := FromOpt(opt.None[string](), fs.ErrNotExist)
+	if !r.Is(fs.ErrNotExist) {
+		t.Errorf("expected none to convert to the provided error but saw %v", r)
+	}
+	if s := FromOpt(opt.Some("x"), fs.ErrNotExist).Unwrap(); s != "x" {
+		t.Errorf("expected some to convert to ok but saw %q", s)
+	}
+}
@@ -0,0 +1,146 @@
+package span
+
+// Len is the number of values in the span. Len is computed without iterating
+// over the span. A span that contains more values than an int can count, such
+// as Closed[uint64](0, math.MaxUint64), has a Len of math.MaxInt; Checked
+// refuses to create such spans.
+func (s Span[T]) Len() int {
+	p, ok := s.prog()
+	if !ok {
+		return 0
+	}
+	if p.count() >= uint64(maxInt) {
+		return maxInt
+	}
+	return int(p.count()) + 1
+}
+
+// Contains reports whether x is one of the values of the span
+func (s Span[T]) Contains(x T) bool {
+	p, ok := s.prog()
+	return ok && p.contains(key(x))
+}
+
+// At gets the value at the i'th position of the span (zero-indexed). Like
+// indexing a slice, At panics if i is out of range.
+func (s Span[T]) At(i int) T {
+	p, ok := s.prog()
+	if !ok || i < 0 || uint64(i) > p.count() {
+		panic("span: index out of range")
+	}
+	return fromKey[T](p.at(uint64(i)))
+}
+
+// IndexOf is the inverse of At: it finds the position of x within the span.
+// If x is not in the span, ok is false.
+func (s Span[T]) IndexOf(x T) (i int, ok bool) {
+	p, ok := s.prog()
+	if !ok || !p.contains(key(x)) {
+		return 0, false
+	}
+	if p.down {
+		return int((p.hi - key(x)) / p.step), true
+	}
+	return int((key(x) - p.lo) / p.step), true
+}
+
+// Reverse creates a span with the same values in the opposite order. Since
+// spans of unsigned integers can't count down, reversing an unsigned span
+// with more than one value fails with ErrStepRange. So does reversing a
+// descending span whose step is the smallest value of T, since its magnitude
+// is one more than the largest T.
+func (s Span[T]) Reverse() (Span[T], error) {
+	p, ok := s.prog()
+	if !ok {
+		return Span[T]{}, nil
+	}
+	p.down = !p.down
+	if r, ok := fromProg[T](p); ok {
+		return r, nil
+	}
+	return Span[T]{}, ErrStepRange
+}
+
+// Intersect creates a span of the values that are in both s and o, in the
+// same order as they appear in s. The steps of both spans are taken into
+// account, e.g., intersecting Step(0, 100, 4) with Step(0, 100, 6) gives the
+// multiples of 12. The intersection has the least common multiple of the two
+// steps as its step; if there is more than one common value and that step
+// can't be represented by T, which can only happen with small integer types,
+// Intersect fails with ErrStepRange.
+func (s Span[T]) Intersect(o Span[T]) (Span[T], error) {
+	p, ok := s.prog()
+	if !ok {
+		return Span[T]{}, nil
+	}
+	q, ok := o.prog()
+	if !ok {
+		return Span[T]{}, nil
+	}
+	both, ok := intersect(p, q)
+	if !ok {
+		return Span[T]{}, nil
+	}
+	if r, ok := fromProg[T](both); ok {
+		return r, nil
+	}
+	return Span[T]{}, ErrStepRange
+}
+
+// Overlaps reports whether s and o have any values in common
+func (s Span[T]) Overlaps(o Span[T]) bool {
+	p, ok := s.prog()
+	if !ok {
+		return false
+	}
+	q, ok := o.prog()
+	if !ok {
+		return false
+	}
+	_, ok = intersect(p, q)
+	return ok
+}
+
+// Split divides the span into n consecutive spans of roughly equal length,
+// e.g., to hand out to n workers. The lengths of the resulting spans differ
+// by at most one and concatenating them gives back the original span. If the
+// span has fewer than n values, it's split into one span per value. Split
+// returns nil if n is less than 1 or the span is empty. Split works even for
+// spans with more values than an int can count, as long as n is large enough
+// that each part's length fits in a uint64.
+func (s Span[T]) Split(n int) []Span[T] {
+	p, ok := s.prog()
+	if !ok || n < 1 {
+		return nil
+	}
+
+	// the span has count+1 values, which doesn't fit in a uint64 for a
+	// span over every value of a 64-bit type, so divide count instead and
+	// then account for the extra value
+	if p.count() < uint64(n) {
+		n = int(p.count()) + 1
+	}
+	size, extra := p.count()/uint64(n), p.count()%uint64(n)+1
+	if extra == uint64(n) {
+		size, extra = size+1, 0
+	}
+	parts := make([]Span[T], n)
+	var at uint64
+	for i := range parts {
+		length := size
+		if uint64(i) < extra {
+			length++
+		}
+		parts[i], _ = fromProg[T](p.slice(at, at+length-1))
+		at += length
+	}
+	return parts
+}
+
+// Shift moves every value of the span by d. Shifting a span past the limits
+// of T wraps around just like adding to a T would.
+func (s Span[T]) Shift(d T) Span[T] {
+	s.Start += d
+	s.End += d
+	return s
+}
@@ -0,0 +1,193 @@
+package span
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestLen(t *testing.T) {
+	cases := []struct {
+		s   Span[int]
+		len int
+	}{
+		{New(0, 10), 10},
+		{New(5, 5), 0},
+		{Step(0, 10, 3), 4},
+		{Step(10, 0, -3), 4},
+		{Closed(0, 10), 11},
+		{Step(0, 10, 0), 0},
+	}
+	for _, c := range cases {
+		if n := c.s.Len(); n != c.len {
+			t.Errorf("expected %v to have length %d but saw %d", c.s, c.len, n)
+		}
+		if n := len(collect[int](c.s)); n != c.len {
+			t.Errorf("expected %v to produce %d values but saw %d", c.s, c.len, n)
+		}
+	}
+
+	if n := Closed[int8](-128, 127).Len(); n != 256 {
+		t.Errorf("expected 256 values in a full int8 span but saw %d", n)
+	}
+
+	for _, s := range []Span[int]{Closed(math.MinInt, math.MaxInt), Closed(0, math.MaxInt), Closed(math.MinInt, 0)} {
+		if n := s.Len(); n != math.MaxInt {
+			t.Errorf("expected %v to have a length of math.MaxInt but saw %d", s, n)
+		}
+	}
+	if n := Closed(1, math.MaxInt).Len(); n != math.MaxInt {
+		t.Errorf("expected a span of exactly math.MaxInt values but saw %d", n)
+	}
+}
+
+func TestContains(t *testing.T) {
+	s := Step(10, -10, -4)
+	for _, x := range []int{10, 6, 2, -2, -6} {
+		if !s.Contains(x) {
+			t.Errorf("expected %v to contain %d", s, x)
+		}
+	}
+	for _, x := range []int{14, 8, -10} {
+		if s.Contains(x) {
+			t.Errorf("expected %v not to contain %d", s, x)
+		}
+	}
+}
+
+func TestAt(t *testing.T) {
+	s := Step(10, -10, -4)
+	for i, v := range collect[int](s) {
+		if at := s.At(i); at != v {
+			t.Errorf("expected At(%d) to be %d but saw %d", i, v, at)
+		}
+		if idx, ok := s.IndexOf(v); !ok || idx != i {
+			t.Errorf("expected IndexOf(%d) to be %d but saw %d, %t", v, i, idx, ok)
+		}
+	}
+	if _, ok := s.IndexOf(7); ok {
+		t.Errorf("7 should not have an index in %v", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected At to panic when out of range")
+		}
+	}()
+	s.At(5)
+}
+
+func TestReverse(t *testing.T) {
+	r, err := Step(0, 10, 3).Reverse()
+	if got := fmt.Sprint(collect[int](r)); err != nil || got != "[9 6 3 0]" {
+		t.Errorf("unexpected reversed span: %s, %v", got, err)
+	}
+
+	full := Closed[int8](-128, 127)
+	if r, err := full.Reverse(); err != nil || r.Len() != 256 || r.At(0) != 127 || r.At(255) != -128 {
+		t.Errorf("unexpected reversed int8 span: %v, %v", r, err)
+	}
+
+	if r, err := New[uint](3, 4).Reverse(); err != nil || r.Len() != 1 || r.At(0) != 3 {
+		t.Errorf("unexpected reversed single value span: %v, %v", r, err)
+	}
+	if _, err := New[uint](0, 10).Reverse(); !errors.Is(err, ErrStepRange) {
+		t.Errorf("expected step range error reversing an unsigned span, saw %v", err)
+	}
+	if _, err := Step[int8](127, -128, -128).Reverse(); !errors.Is(err, ErrStepRange) {
+		t.Errorf("expected step range error reversing a span with the smallest step, saw %v", err)
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	cases := []struct {
+		a, b Span[int]
+		out  string
+	}{
+		{New(0, 10), New(5, 15), "[5 6 7 8 9]"},
+		{Step(0, 100, 4), Step(0, 100, 6), "[0 12 24 36 48 60 72 84 96]"},
+		{Step(1, 30, 4), Step(3, 30, 6), "[9 21]"},
+		{Step(0, 30, 2), Step(1, 30, 2), "[]"},
+		{New(0, 10), New(10, 20), "[]"},
+		{Step(20, 0, -5), ClosedStep(0, 20, 2), "[20 10]"},
+	}
+	for _, c := range cases {
+		both, err := c.a.Intersect(c.b)
+		if err != nil {
+			t.Errorf("%v ∩ %v: unexpected error: %v", c.a, c.b, err)
+		}
+		got := fmt.Sprint(collect[int](both))
+		if got != c.out {
+			t.Errorf("%v ∩ %v: expected %s but saw %s", c.a, c.b, c.out, got)
+		}
+		if overlaps := c.a.Overlaps(c.b); overlaps != (c.out != "[]") {
+			t.Errorf("%v overlaps %v: expected %t", c.a, c.b, !overlaps)
+		}
+	}
+
+	small, err := Step[uint8](0, 255, 5).Intersect(Closed[uint8](240, 255))
+	if got := fmt.Sprint(collect[uint8](small)); err != nil || got != "[240 245 250]" {
+		t.Errorf("unexpected uint8 intersection: %s, %v", got, err)
+	}
+
+	_, err = Step[int8](-128, 127, 10).Intersect(Step[int8](-128, 127, 13))
+	if !errors.Is(err, ErrStepRange) {
+		t.Errorf("expected step range error for an lcm step of 130, saw %v", err)
+	}
+	one, err := Step[int8](-128, 127, 10).Intersect(Step[int8](-128, 0, 13))
+	if err != nil || one.Len() != 1 || one.At(0) != -128 {
+		t.Errorf("unexpected single value intersection: %v, %v", one, err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	s := Step(0, 100, 3)
+	parts := s.Split(4)
+	if len(parts) != 4 {
+		t.Fatalf("expected 4 parts but saw %d", len(parts))
+	}
+
+	var joined []int
+	for _, p := range parts {
+		if n := p.Len(); n < 8 || n > 9 {
+			t.Errorf("expected parts of length 8 or 9 but saw %d", n)
+		}
+		joined = append(joined, collect[int](p)...)
+	}
+	if fmt.Sprint(joined) != fmt.Sprint(collect[int](s)) {
+		t.Errorf("split parts do not join back into the original span: %v", joined)
+	}
+
+	if n := len(New(0, 3).Split(10)); n != 3 {
+		t.Errorf("expected 3 parts when splitting 3 values but saw %d", n)
+	}
+	if New(0, 3).Split(0) != nil {
+		t.Errorf("expected nil when splitting into no parts")
+	}
+
+	down := fmt.Sprint(collect[int](Step(10, 0, -1).Split(2)[1]))
+	if down != "[5 4 3 2 1]" {
+		t.Errorf("unexpected second half of descending span: %s", down)
+	}
+
+	full := Closed[uint64](0, math.MaxUint64).Split(4)
+	if len(full) != 4 {
+		t.Fatalf("expected 4 parts of a full uint64 span but saw %d", len(full))
+	}
+	for i, p := range full {
+		if p.Start != uint64(i)<<62 || p.End != uint64(i)<<62+1<<62-1 {
+			t.Errorf("unexpected part %d of a full uint64 span: %v", i, p)
+		}
+	}
+	if odd := Closed[int64](math.MinInt64, math.MaxInt64).Split(3); len(odd) != 3 || odd[2].End != math.MaxInt64 || odd[1].Start != odd[0].End+1 {
+		t.Errorf("unexpected thirds of a full int64 span: %v", odd)
+	}
+}
+
+func TestShift(t *testing.T) {
+	got := fmt.Sprint(collect[int](Step(0, 10, 3).Shift(5)))
+	if got != "[5 8 11 14]" {
+		t.Errorf("unexpected shifted span: %s", got)
+	}
+}
@@ -0,0 +1,91 @@
+package span
+
+import (
+	"constraints"
+	"math"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+// Float is a span of floating point numbers, e.g., Float[float64]{0, 1, 0.1}
+// represents 0, 0.1, 0.2 ... 0.9. Like Span, a negative Step counts down and
+// End is not part of the span.
+//
+// Repeatedly adding a step such as 0.1 accumulates rounding error, so that
+// the tenth value would be 0.9999999999999999 and the span would produce an
+// extra value. Instead, the i'th value of a Float is always computed as
+// Start + i*Step, which is off from the ideal value by at most one rounding.
+type Float[T constraints.Float] struct {
+	Start T
+	End   T
+	Step  T
+}
+
+// FloatStep creates a span of floating point numbers from start to end
+func FloatStep[T constraints.Float](start, end, step T) Float[T] {
+	return Float[T]{Start: start, End: end, Step: step}
+}
+
+// At gets the i'th value of the span, without checking whether it's past the
+// end of the span
+func (f Float[T]) At(i int) T { return f.Start + T(i)*f.Step }
+
+// before reports whether v hasn't reached the end of the span yet
+func (f Float[T]) before(v T) bool {
+	switch {
+	case f.Step > 0:
+		return v < f.End
+	case f.Step < 0:
+		return v > f.End
+	default:
+		// zero and NaN steps never get anywhere
+		return false
+	}
+}
+
+// Len is the number of values in the span. It's estimated by division and
+// then corrected for rounding by checking the values on either side of the
+// estimate, so that it always agrees with iteration. A span with more values
+// than an int can count, such as a span with an infinite End, has a Len of
+// math.MaxInt.
+func (f Float[T]) Len() int {
+	if !f.before(f.Start) {
+		return 0
+	}
+	est := math.Ceil(float64((f.End - f.Start) / f.Step))
+	if math.IsNaN(est) || est >= float64(maxInt) {
+		return maxInt
+	}
+	n := int(est)
+	for n > 0 && !f.before(f.At(n-1)) {
+		n--
+	}
+	for n < maxInt && f.before(f.At(n)) {
+		n++
+	}
+	return n
+}
+
+type floatIter[T constraints.Float] struct {
+	span Float[T]
+	i    int
+	done bool
+}
+
+func (f Float[T]) Iter() iter.Ator[T] { return &floatIter[T]{span: f} }
+
+func (it *floatIter[T]) Next(v *T) bool {
+	if it.done {
+		return false
+	}
+	next := it.span.At(it.i)
+	if !it.span.before(next) {
+		it.done = true
+		return false
+	}
+	*v = next
+	it.i++
+	return true
+}
+
+func (it floatIter[T]) Iter() iter.Ator[T] { return &it }
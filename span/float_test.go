@@ -0,0 +1,44 @@
+package span
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+func TestFloat(t *testing.T) {
+	vals := collect[float64](FloatStep(0.0, 1.0, 0.1))
+	if len(vals) != 10 {
+		t.Fatalf("expected 10 values but saw %d: %v", len(vals), vals)
+	}
+	if n := FloatStep(0.0, 1.0, 0.1).Len(); n != 10 {
+		t.Errorf("expected length 10 but saw %d", n)
+	}
+
+	down := fmt.Sprint(collect[float32](FloatStep[float32](1, 0, -0.25)))
+	if down != "[1 0.75 0.5 0.25]" {
+		t.Errorf("unexpected descending floats: %s", down)
+	}
+
+	for _, f := range []Float[float64]{{0, 1, 0}, {0, 1, -0.5}, {1, 1, 0.5}} {
+		if n := len(collect[float64](f)); n != 0 || f.Len() != 0 {
+			t.Errorf("expected %v to be empty", f)
+		}
+	}
+
+	for _, f := range []Float[float64]{
+		FloatStep(0, math.Inf(1), 1),
+		FloatStep(0, 1e30, 1e-10),
+		FloatStep(0, math.Inf(-1), -1),
+	} {
+		if n := f.Len(); n != math.MaxInt {
+			t.Errorf("expected %v to have a length of math.MaxInt but saw %d", f, n)
+		}
+	}
+
+	if max := iter.Max[float64](FloatStep(0.0, 3.0, 0.5)); max != 2.5 {
+		t.Errorf("expected max of 2.5 but saw %v", max)
+	}
+}
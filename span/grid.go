@@ -0,0 +1,171 @@
+package span
+
+import (
+	"constraints"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+// Order is the order in which the points of a Grid are visited
+type Order int
+
+const (
+	// RowMajor visits the points of a grid such that the last dimension
+	// changes fastest, like nesting loops over the dimensions in order
+	RowMajor Order = iota
+
+	// ColumnMajor visits the points of a grid such that the first dimension
+	// changes fastest
+	ColumnMajor
+)
+
+// Grid is the Cartesian product of some number of spans. A grid is iterable,
+// producing one point per combination of the values of its spans, where a
+// point is a slice with one value per dimension. E.g., the pixels of an image
+// can be visited with:
+//
+//     g := span.NewGrid(span.New(0, height), span.New(0, width))
+//     for p, it := iter.Start[[]int](g); it.Next(&p); {
+//         y, x := p[0], p[1]
+//     }
+//
+// Every point produced by a grid is a newly allocated slice, so points may be
+// retained or handed off to other goroutines.
+type Grid[T constraints.Integer] struct {
+	Dims  []Span[T]
+	Order Order
+}
+
+// NewGrid creates a row-major grid over the provided spans
+func NewGrid[T constraints.Integer](dims ...Span[T]) Grid[T] {
+	return Grid[T]{Dims: dims}
+}
+
+// Len is the number of points in the grid. A grid without dimensions has no
+// points. Like Span.Len, a grid with more points than an int can count has a
+// Len of math.MaxInt.
+func (g Grid[T]) Len() int {
+	if len(g.Dims) == 0 {
+		return 0
+	}
+	n := 1
+	for _, d := range g.Dims {
+		l := d.Len()
+		if l == 0 {
+			return 0
+		}
+		if n > maxInt/l {
+			n = maxInt
+		} else {
+			n *= l
+		}
+	}
+	return n
+}
+
+// dim gets the index of the dimension that changes i'th fastest
+func (g Grid[T]) dim(i int) int {
+	if g.Order == ColumnMajor {
+		return i
+	}
+	return len(g.Dims) - 1 - i
+}
+
+// At gets the i'th point of the grid in the grid's order. Like indexing a
+// slice, At panics if i is out of range.
+func (g Grid[T]) At(i int) []T {
+	if i < 0 || i >= g.Len() {
+		panic("span: grid index out of range")
+	}
+	p := make([]T, len(g.Dims))
+	for j := range g.Dims {
+		d := g.dim(j)
+		n := g.Dims[d].Len()
+		p[d] = g.Dims[d].At(i % n)
+		i /= n
+	}
+	return p
+}
+
+type gridIter[T constraints.Integer] struct {
+	grid Grid[T]
+	i    int
+	n    int
+}
+
+func (g Grid[T]) Iter() iter.Ator[[]T] { return &gridIter[T]{grid: g, n: g.Len()} }
+
+func (it *gridIter[T]) Next(p *[]T) bool {
+	if it.i >= it.n {
+		return false
+	}
+	*p = it.grid.At(it.i)
+	it.i++
+	return true
+}
+
+func (it gridIter[T]) Iter() iter.Ator[[]T] { return &it }
+
+// Tile cuts the grid into sub-grids of at most sizes[d] values along each
+// dimension d, e.g., to hand 64x64 tiles of an image to a worker pool. A size
+// less than 1, or a missing size, leaves that dimension uncut. Together the
+// tiles cover every point of the grid exactly once, and they are listed in
+// the grid's order.
+func (g Grid[T]) Tile(sizes ...int) []Grid[T] {
+	if g.Len() == 0 {
+		return nil
+	}
+
+	cuts := make([][]Span[T], len(g.Dims))
+	for d, dim := range g.Dims {
+		size := 0
+		if d < len(sizes) {
+			size = sizes[d]
+		}
+		cuts[d] = dim.chunks(size)
+	}
+
+	shape := make([]Span[int], len(cuts))
+	for d := range cuts {
+		shape[d] = New(0, len(cuts[d]))
+	}
+	which := Grid[int]{Dims: shape, Order: g.Order}
+
+	tiles := make([]Grid[T], which.Len())
+	for i := range tiles {
+		dims := make([]Span[T], len(g.Dims))
+		for d, c := range which.At(i) {
+			dims[d] = cuts[d][c]
+		}
+		tiles[i] = Grid[T]{Dims: dims, Order: g.Order}
+	}
+	return tiles
+}
+
+// chunks cuts the span into consecutive spans of size values each, except
+// for the last one which may be shorter. A size less than 1 leaves the span
+// whole.
+func (s Span[T]) chunks(size int) []Span[T] {
+	p, ok := s.prog()
+	if !ok {
+		return nil
+	}
+	if size < 1 {
+		return []Span[T]{s}
+	}
+
+	// stop upon reaching the last value rather than once past it, since for
+	// a span over every value of a 64-bit type, going past it wraps around
+	var out []Span[T]
+	for at := uint64(0); ; at += uint64(size) {
+		end := at + uint64(size) - 1
+		if end > p.count() || end < at {
+			end = p.count()
+		}
+		chunk, _ := fromProg[T](p.slice(at, end))
+		out = append(out, chunk)
+		if end == p.count() {
+			return out
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package span
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestGrid(t *testing.T) {
+	g := NewGrid(New(0, 2), Step(10, 40, 10))
+	if n := g.Len(); n != 6 {
+		t.Errorf("expected 6 points but saw %d", n)
+	}
+
+	rows := fmt.Sprint(collect[[]int](g))
+	if rows != "[[0 10] [0 20] [0 30] [1 10] [1 20] [1 30]]" {
+		t.Errorf("unexpected row-major points: %s", rows)
+	}
+
+	g.Order = ColumnMajor
+	cols := fmt.Sprint(collect[[]int](g))
+	if cols != "[[0 10] [1 10] [0 20] [1 20] [0 30] [1 30]]" {
+		t.Errorf("unexpected column-major points: %s", cols)
+	}
+	if p := g.At(3); fmt.Sprint(p) != "[1 20]" {
+		t.Errorf("unexpected point at 3: %v", p)
+	}
+
+	if n := NewGrid(New(0, 2), New(0, 0)).Len(); n != 0 {
+		t.Errorf("a grid with an empty dimension should be empty but has %d points", n)
+	}
+	if n := NewGrid[int]().Len(); n != 0 {
+		t.Errorf("a grid without dimensions should be empty but has %d points", n)
+	}
+
+	huge := NewGrid(New[uint64](0, 3), Closed[uint64](0, math.MaxUint64))
+	if n := huge.Len(); n != math.MaxInt {
+		t.Errorf("expected a grid too big to count to have a length of math.MaxInt but saw %d", n)
+	}
+	var p []uint64
+	if it := huge.Iter(); !it.Next(&p) || !it.Next(&p) || fmt.Sprint(p) != "[0 1]" {
+		t.Errorf("unexpected second point of a huge grid: %v", p)
+	}
+	if tiles := huge.Tile(0, 1<<62); len(tiles) != 4 || tiles[3].Dims[1].End != math.MaxUint64 {
+		t.Errorf("unexpected tiles of a huge grid: %v", tiles)
+	}
+}
+
+func TestGridTile(t *testing.T) {
+	g := NewGrid(New(0, 5), New(0, 4))
+	tiles := g.Tile(2, 3)
+	if len(tiles) != 6 {
+		t.Fatalf("expected 6 tiles but saw %d", len(tiles))
+	}
+	if got := fmt.Sprint(tiles[1].Dims); got != "[0-1 3]" {
+		t.Errorf("unexpected second tile: %s", got)
+	}
+
+	seen := make(map[string]int)
+	total := 0
+	for _, tile := range tiles {
+		for _, p := range collect[[]int](tile) {
+			seen[fmt.Sprint(p)]++
+			total++
+		}
+	}
+	if total != g.Len() || len(seen) != g.Len() {
+		t.Errorf("tiles should cover all %d points exactly once but covered %d points %d times", g.Len(), len(seen), total)
+	}
+
+	if n := len(g.Tile(2)); n != 3 {
+		t.Errorf("expected missing sizes to leave dimensions uncut, saw %d tiles", n)
+	}
+}
@@ -0,0 +1,204 @@
+package span
+
+import (
+	"constraints"
+	"math/big"
+)
+
+// The arithmetic on spans is done on keys rather than directly on values of
+// T. A key is a uint64 that preserves the ordering of T: unsigned values are
+// widened as they are and signed values are widened and then have their sign
+// bit flipped, which maps the smallest value of a signed type to the middle
+// of the uint64 range, just below where zero ends up. The difference between
+// two keys is always the true distance between the two values, so none of the
+// span math has to worry about signedness or wrapping around.
+
+// signed is true if T is a signed integer type
+func signed[T constraints.Integer]() bool {
+	var zero T
+	return ^zero < zero
+}
+
+const signBit = uint64(1) << 63
+
+func key[T constraints.Integer](v T) uint64 {
+	if signed[T]() {
+		return uint64(v) ^ signBit
+	}
+	return uint64(v)
+}
+
+func fromKey[T constraints.Integer](k uint64) T {
+	if signed[T]() {
+		return T(k ^ signBit)
+	}
+	return T(k)
+}
+
+// prog is a non-empty span boiled down to an arithmetic progression of keys:
+// lo, lo+step, lo+2*step, ..., hi. If down is true, the span visits those
+// keys from hi to lo.
+type prog struct {
+	lo   uint64
+	hi   uint64
+	step uint64
+	down bool
+}
+
+func (p prog) first() uint64 {
+	if p.down {
+		return p.hi
+	}
+	return p.lo
+}
+
+func (p prog) last() uint64 {
+	if p.down {
+		return p.lo
+	}
+	return p.hi
+}
+
+// count is the number of values in the progression minus one. The full count
+// would not fit in a uint64 for a unit-step span over every uint64.
+func (p prog) count() uint64 { return (p.hi - p.lo) / p.step }
+
+// at is the key of the i'th value visited
+func (p prog) at(i uint64) uint64 {
+	if p.down {
+		return p.hi - i*p.step
+	}
+	return p.lo + i*p.step
+}
+
+// slice is the part of the progression from its i'th to its j'th value,
+// inclusive
+func (p prog) slice(i, j uint64) prog {
+	if p.down {
+		return prog{lo: p.at(j), hi: p.at(i), step: p.step, down: true}
+	}
+	return prog{lo: p.at(i), hi: p.at(j), step: p.step}
+}
+
+// contains reports whether the key k is one of the keys of the progression
+func (p prog) contains(k uint64) bool {
+	return k >= p.lo && k <= p.hi && (k-p.lo)%p.step == 0
+}
+
+// prog works out the progression of keys visited by s. If s is empty, ok is
+// false.
+func (s Span[T]) prog() (p prog, ok bool) {
+	var zero T
+	start, end := key(s.Start), key(s.End)
+	switch {
+	case s.Step > zero:
+		if end < start || (end == start && !s.Inclusive) {
+			return prog{}, false
+		}
+		dist, mag := end-start, uint64(s.Step)
+		if !s.Inclusive {
+			dist--
+		}
+		return prog{lo: start, hi: start + dist/mag*mag, step: mag}, true
+	case s.Step < zero:
+		if end > start || (end == start && !s.Inclusive) {
+			return prog{}, false
+		}
+		// s.Step is sign-extended when widened, so negating it as a uint64
+		// gives its magnitude, even for the smallest value of T.
+		dist, mag := start-end, -uint64(s.Step)
+		if !s.Inclusive {
+			dist--
+		}
+		return prog{lo: start - dist/mag*mag, hi: start, step: mag, down: true}, true
+	default:
+		return prog{}, false
+	}
+}
+
+// fromProg creates a closed span that visits the keys of p. The step of p
+// has to be representable as a T, which is only a problem for progressions
+// with more than one value and a step larger than the largest T, or for
+// descending progressions over unsigned types; ok is false for those. Any
+// slice of the progression of a span is always representable.
+func fromProg[T constraints.Integer](p prog) (s Span[T], ok bool) {
+	first, last := fromKey[T](p.first()), fromKey[T](p.last())
+	if p.lo == p.hi {
+		return Closed(first, last), true
+	}
+
+	var zero T
+	if p.down {
+		step := T(-p.step)
+		if step >= zero || -uint64(step) != p.step {
+			return Span[T]{}, false
+		}
+		return ClosedStep(first, last, step), true
+	}
+	step := T(p.step)
+	if step <= zero || uint64(step) != p.step {
+		return Span[T]{}, false
+	}
+	return ClosedStep(first, last, step), true
+}
+
+// intersect finds the progression of keys common to both a and b, which are
+// visited in the same direction as a. The values common to both are the
+// solutions x of the system
+//
+//     x ≡ a.lo (mod a.step)
+//     x ≡ b.lo (mod b.step)
+//
+// that lie within both progressions. Per the Chinese remainder theorem, there
+// are solutions if and only if a.lo and b.lo are congruent modulo the gcd of
+// the steps, in which case the solutions are spaced by the lcm of the steps.
+// The lcm of two uint64 values needs up to 128 bits, so this is done with
+// math/big.
+func intersect(a, b prog) (prog, bool) {
+	lo, hi := a.lo, a.hi
+	if b.lo > lo {
+		lo = b.lo
+	}
+	if b.hi < hi {
+		hi = b.hi
+	}
+	if lo > hi {
+		return prog{}, false
+	}
+
+	// Measure everything as an offset from lo; c1 and c2 are the offsets of
+	// the first values of a and b that are at or above lo.
+	m1, m2 := new(big.Int).SetUint64(a.step), new(big.Int).SetUint64(b.step)
+	c1 := new(big.Int).SetUint64((a.step - (lo-a.lo)%a.step) % a.step)
+	c2 := new(big.Int).SetUint64((b.step - (lo-b.lo)%b.step) % b.step)
+
+	g, inv := new(big.Int), new(big.Int)
+	g.GCD(inv, nil, m1, m2)
+
+	diff := new(big.Int).Sub(c2, c1)
+	if new(big.Int).Mod(diff, g).Sign() != 0 {
+		return prog{}, false
+	}
+
+	// offset = c1 + m1 * ((c2-c1)/g * inv mod m2/g), where inv is the inverse
+	// of m1/g modulo m2/g as produced by the extended gcd
+	m2g := new(big.Int).Quo(m2, g)
+	t := new(big.Int).Quo(diff, g)
+	t.Mul(t, inv).Mod(t, m2g)
+	offset := t.Mul(t, m1).Add(t, c1)
+
+	width := new(big.Int).SetUint64(hi - lo)
+	if offset.Cmp(width) > 0 {
+		return prog{}, false
+	}
+
+	lcm := new(big.Int).Mul(m1, m2g)
+	first := lo + offset.Uint64()
+	rest := hi - first
+	p := prog{lo: first, hi: first, step: 1, down: a.down}
+	if lcm.IsUint64() && lcm.Uint64() <= rest {
+		p.step = lcm.Uint64()
+		p.hi = first + rest/p.step*p.step
+	}
+	return p, true
+}
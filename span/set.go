@@ -0,0 +1,239 @@
+package span
+
+import (
+	"constraints"
+	"sort"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+// ival is a closed interval of keys
+type ival struct {
+	lo uint64
+	hi uint64
+}
+
+// Set is a set of integers stored as a sorted collection of disjoint spans.
+// The spans of a set are always normalized: they have a step of 1, they
+// don't overlap, and they don't touch, so that e.g. adding 1-5 and then 6-10
+// results in a single span 1-10. The zero value is an empty set ready to use.
+type Set[T constraints.Integer] struct {
+	ivs []ival
+}
+
+// NewSet creates a set containing every value of the provided spans
+func NewSet[T constraints.Integer](spans ...Span[T]) *Set[T] {
+	var s Set[T]
+	for _, sp := range spans {
+		s.Add(sp)
+	}
+	return &s
+}
+
+// Add adds every value of the span sp to the set. Spans with a step of 1 or
+// -1 are added in one go; other spans are added one value at a time.
+func (s *Set[T]) Add(sp Span[T]) {
+	p, ok := sp.prog()
+	if !ok {
+		return
+	}
+	if p.step == 1 || p.lo == p.hi {
+		s.add(p.lo, p.hi)
+		return
+	}
+	for i := uint64(0); i <= p.count(); i++ {
+		k := p.at(i)
+		s.add(k, k)
+	}
+}
+
+// Remove removes every value of the span sp from the set
+func (s *Set[T]) Remove(sp Span[T]) {
+	p, ok := sp.prog()
+	if !ok {
+		return
+	}
+	if p.step == 1 || p.lo == p.hi {
+		s.remove(p.lo, p.hi)
+		return
+	}
+	for i := uint64(0); i <= p.count(); i++ {
+		k := p.at(i)
+		s.remove(k, k)
+	}
+}
+
+// add merges the interval lo-hi into the set, along with every interval it
+// overlaps or touches
+func (s *Set[T]) add(lo, hi uint64) {
+	// i is the first interval that doesn't end before lo with a gap in
+	// between, j is the first interval that starts after hi with a gap in
+	// between. Everything from i to j gets merged with lo-hi.
+	i := sort.Search(len(s.ivs), func(i int) bool {
+		return s.ivs[i].hi >= lo || s.ivs[i].hi+1 == lo
+	})
+	j := sort.Search(len(s.ivs), func(j int) bool {
+		return s.ivs[j].lo > hi && s.ivs[j].lo-1 != hi
+	})
+
+	merged := ival{lo: lo, hi: hi}
+	if i < j {
+		if s.ivs[i].lo < merged.lo {
+			merged.lo = s.ivs[i].lo
+		}
+		if s.ivs[j-1].hi > merged.hi {
+			merged.hi = s.ivs[j-1].hi
+		}
+	}
+
+	ivs := make([]ival, 0, len(s.ivs)-(j-i)+1)
+	ivs = append(ivs, s.ivs[:i]...)
+	ivs = append(ivs, merged)
+	s.ivs = append(ivs, s.ivs[j:]...)
+}
+
+// remove cuts the interval lo-hi out of the set
+func (s *Set[T]) remove(lo, hi uint64) {
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].hi >= lo })
+	j := sort.Search(len(s.ivs), func(j int) bool { return s.ivs[j].lo > hi })
+	if i >= j {
+		return
+	}
+
+	// at most two pieces survive: the part of the first interval below lo
+	// and the part of the last interval above hi
+	var keep []ival
+	if first := s.ivs[i]; first.lo < lo {
+		keep = append(keep, ival{lo: first.lo, hi: lo - 1})
+	}
+	if last := s.ivs[j-1]; last.hi > hi {
+		keep = append(keep, ival{lo: hi + 1, hi: last.hi})
+	}
+
+	ivs := make([]ival, 0, len(s.ivs)-(j-i)+len(keep))
+	ivs = append(ivs, s.ivs[:i]...)
+	ivs = append(ivs, keep...)
+	s.ivs = append(ivs, s.ivs[j:]...)
+}
+
+// Contains reports whether x is in the set
+func (s *Set[T]) Contains(x T) bool {
+	k := key(x)
+	i := sort.Search(len(s.ivs), func(i int) bool { return s.ivs[i].hi >= k })
+	return i < len(s.ivs) && s.ivs[i].lo <= k
+}
+
+// Empty is true for sets with no values
+func (s *Set[T]) Empty() bool { return len(s.ivs) == 0 }
+
+// Len is the number of values in the set. Like Span.Len, it's undefined for
+// sets with more values than an int can count.
+func (s *Set[T]) Len() int {
+	n := 0
+	for _, iv := range s.ivs {
+		n += int(iv.hi-iv.lo) + 1
+	}
+	return n
+}
+
+// Union creates a new set with the values that are in either s or o
+func (s *Set[T]) Union(o *Set[T]) *Set[T] {
+	out := s.clone()
+	for _, iv := range o.ivs {
+		out.add(iv.lo, iv.hi)
+	}
+	return out
+}
+
+// Intersect creates a new set with the values that are in both s and o
+func (s *Set[T]) Intersect(o *Set[T]) *Set[T] {
+	var out Set[T]
+	for i, j := 0, 0; i < len(s.ivs) && j < len(o.ivs); {
+		a, b := s.ivs[i], o.ivs[j]
+		lo, hi := a.lo, a.hi
+		if b.lo > lo {
+			lo = b.lo
+		}
+		if b.hi < hi {
+			hi = b.hi
+		}
+		if lo <= hi {
+			out.ivs = append(out.ivs, ival{lo: lo, hi: hi})
+		}
+		// whichever interval ends first can't overlap anything else
+		if a.hi < b.hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return &out
+}
+
+// Difference creates a new set with the values of s that are not in o
+func (s *Set[T]) Difference(o *Set[T]) *Set[T] {
+	out := s.clone()
+	for _, iv := range o.ivs {
+		out.remove(iv.lo, iv.hi)
+	}
+	return out
+}
+
+// Complement creates a new set with the values of the span bound that are
+// not in s. E.g., given a set of allocated ports, the complement within
+// Closed(1024, 65535) is the set of free ports.
+func (s *Set[T]) Complement(bound Span[T]) *Set[T] {
+	return NewSet(bound).Difference(s)
+}
+
+func (s *Set[T]) clone() *Set[T] {
+	return &Set[T]{ivs: append([]ival(nil), s.ivs...)}
+}
+
+// Spans lists the normalized spans of the set in ascending order. Each span
+// is closed and has a step of 1.
+func (s *Set[T]) Spans() []Span[T] {
+	spans := make([]Span[T], len(s.ivs))
+	for i, iv := range s.ivs {
+		spans[i] = Closed(fromKey[T](iv.lo), fromKey[T](iv.hi))
+	}
+	return spans
+}
+
+// SpanIter iterates over the normalized spans of the set in ascending order
+func (s *Set[T]) SpanIter() iter.Able[Span[T]] { return iter.Slice(s.Spans()) }
+
+type setIter[T constraints.Integer] struct {
+	ivs  []ival
+	next uint64
+	done bool
+}
+
+// Iter iterates over every value of the set in ascending order
+func (s *Set[T]) Iter() iter.Ator[T] {
+	it := &setIter[T]{ivs: s.ivs, done: len(s.ivs) == 0}
+	if !it.done {
+		it.next = s.ivs[0].lo
+	}
+	return it
+}
+
+func (it *setIter[T]) Next(v *T) bool {
+	if it.done {
+		return false
+	}
+	*v = fromKey[T](it.next)
+	if it.next < it.ivs[0].hi {
+		it.next++
+		return true
+	}
+	it.ivs = it.ivs[1:]
+	if len(it.ivs) == 0 {
+		it.done = true
+	} else {
+		it.next = it.ivs[0].lo
+	}
+	return true
+}
+
+func (it setIter[T]) Iter() iter.Ator[T] { return &it }
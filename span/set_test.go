@@ -0,0 +1,121 @@
+package span
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+func TestSetAdd(t *testing.T) {
+	var s Set[int]
+	s.Add(Closed(1, 5))
+	s.Add(Closed(6, 10))
+	s.Add(Closed(20, 30))
+	s.Add(Closed(25, 35))
+	s.Add(Step(50, 40, -1))
+
+	got := fmt.Sprint(s.Spans())
+	if got != "[1-10 20-35 41-50]" {
+		t.Errorf("unexpected spans: %s", got)
+	}
+
+	s.Add(Closed(11, 19))
+	if n := len(s.Spans()); n != 2 {
+		t.Errorf("filling the gap should merge spans but saw %v", s.Spans())
+	}
+
+	s.Add(Step(100, 110, 5))
+	if vals := fmt.Sprint(collect[int](NewSet(Step(100, 110, 5)))); vals != "[100 105]" {
+		t.Errorf("unexpected values for stepped span: %s", vals)
+	}
+
+	for _, x := range []int{1, 19, 35, 100, 105} {
+		if !s.Contains(x) {
+			t.Errorf("expected set to contain %d", x)
+		}
+	}
+	for _, x := range []int{0, 36, 101, 110} {
+		if s.Contains(x) {
+			t.Errorf("expected set not to contain %d", x)
+		}
+	}
+}
+
+func TestSetRemove(t *testing.T) {
+	s := NewSet(Closed(1, 100))
+	s.Remove(Closed(10, 19))
+	s.Remove(Closed(50, 200))
+	s.Remove(Closed(-5, 1))
+
+	got := fmt.Sprint(s.Spans())
+	if got != "[2-9 20-49]" {
+		t.Errorf("unexpected spans: %s", got)
+	}
+	if n := s.Len(); n != 38 {
+		t.Errorf("expected 38 values but saw %d", n)
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := NewSet(Closed(1, 10), Closed(20, 30))
+	b := NewSet(Closed(5, 25))
+
+	cases := []struct {
+		name string
+		set  *Set[int]
+		out  string
+	}{
+		{"union", a.Union(b), "[1-30]"},
+		{"intersect", a.Intersect(b), "[5-10 20-25]"},
+		{"difference", a.Difference(b), "[1-4 26-30]"},
+		{"complement", a.Complement(New(0, 40)), "[0 11-19 31-39]"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprint(c.set.Spans()); got != c.out {
+			t.Errorf("%s: expected %s but saw %s", c.name, c.out, got)
+		}
+	}
+
+	if got := fmt.Sprint(a.Spans()); got != "[1-10 20-30]" {
+		t.Errorf("set algebra should not modify its inputs but saw %s", got)
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	s := NewSet(Closed[uint8](250, 255), Closed[uint8](0, 3))
+	s.Add(Closed[uint8](4, 249))
+	if got := fmt.Sprint(s.Spans()); got != "[0-255]" {
+		t.Errorf("unexpected spans: %s", got)
+	}
+	s.Remove(Closed[uint8](0, 0))
+	s.Remove(Closed[uint8](255, 255))
+	if got := fmt.Sprint(s.Spans()); got != "[1-254]" {
+		t.Errorf("unexpected spans: %s", got)
+	}
+
+	signed := NewSet(Closed[int8](-128, -100), Closed[int8](-99, 127))
+	if n := len(signed.Spans()); n != 1 {
+		t.Errorf("expected touching int8 spans to merge but saw %v", signed.Spans())
+	}
+}
+
+func TestSetIter(t *testing.T) {
+	s := NewSet(Closed(1, 3), Closed(7, 8))
+	if got := fmt.Sprint(collect[int](s)); got != "[1 2 3 7 8]" {
+		t.Errorf("unexpected values: %s", got)
+	}
+
+	n := 0
+	for sp, it := iter.Start(s.SpanIter()); it.Next(&sp); {
+		n += sp.Len()
+	}
+	if n != 5 {
+		t.Errorf("expected spans to cover 5 values but saw %d", n)
+	}
+
+	var empty Set[int]
+	if vals := collect[int](&empty); len(vals) != 0 {
+		t.Errorf("empty set should have no values but saw %v", vals)
+	}
+}
@@ -2,39 +2,82 @@ package span
 
 import (
 	"constraints"
+	"errors"
 
 	"github.com/jordanorelli/generic/iter"
 )
 
-// Span represents some range of integers
+var (
+	// ErrZeroStep is returned when creating a span whose step is zero, which
+	// would never reach its end
+	ErrZeroStep = errors.New("span step is zero")
+
+	// ErrDirection is returned when creating a span whose step points away
+	// from its end, such that the span is empty
+	ErrDirection = errors.New("span step points away from span end")
+
+	// ErrTooLong is returned when creating a span that contains more values
+	// than can be counted with an int
+	ErrTooLong = errors.New("span is too long")
+
+	// ErrStepRange is returned when the result of an operation on spans
+	// would need a step that can't be represented by the span's type, e.g.,
+	// reversing a span of unsigned integers
+	ErrStepRange = errors.New("span step is out of range for the span's type")
+)
+
+// Span represents some range of integers. A span with a positive Step counts
+// up from Start towards End and a span with a negative Step counts down from
+// Start towards End. End itself is only part of the span if Inclusive is
+// true. Since the step of a span has the same type as its values, spans of
+// unsigned integers can only count up.
 type Span[T constraints.Integer] struct {
-	Start T
-	End T
-	Step T
+	Start     T
+	End       T
+	Step      T
+	Inclusive bool
+}
+
+// bounds works out the first and last values that the span produces. If the
+// span is empty, ok is false.
+func (s Span[T]) bounds() (first, last T, ok bool) {
+	p, ok := s.prog()
+	if !ok {
+		var zero T
+		return zero, zero, false
+	}
+	return fromKey[T](p.first()), fromKey[T](p.last()), true
 }
 
 type spanIter[T constraints.Integer] struct {
-	start T
-	end T
-	step T
 	next T
+	last T
+	step T
+	done bool
 }
 
 func (s Span[T]) Iter() iter.Ator[T] {
+	first, last, ok := s.bounds()
 	return &spanIter[T]{
-		start: s.Start,
-		end: s.End,
+		next: first,
+		last: last,
 		step: s.Step,
-		next: s.Start,
+		done: !ok,
 	}
 }
 
+// Next stops upon producing the last value of the span rather than once the
+// end has been passed, so that stepping past the end can never overflow.
 func (s *spanIter[T]) Next(n *T) bool {
-	if s.step == 0 || s.next < s.start || s.next >= s.end {
+	if s.done {
 		return false
 	}
 	*n = s.next
-	s.next += s.step
+	if s.next == s.last {
+		s.done = true
+	} else {
+		s.next += s.step
+	}
 	return true
 }
 
@@ -52,7 +95,9 @@ func New[T constraints.Integer](start, end T) Span[T] {
 	}
 }
 
-// Step is the same as creating a span with a provided step value
+// Step is the same as creating a span with a provided step value. A negative
+// step creates a span that counts down, e.g., Step(10, 0, -1) produces the
+// values 10 through 1.
 func Step[T constraints.Integer](start, end, step T) Span[T] {
 	return Span[T]{
 		Start: start,
@@ -60,3 +105,42 @@ func Step[T constraints.Integer](start, end, step T) Span[T] {
 		Step: step,
 	}
 }
+
+// Closed creates a span of integers from start to end that includes end,
+// with a step size of 1. Closed is able to express spans that reach the
+// largest value of T, which New cannot; e.g., Closed[uint8](0, 255).
+func Closed[T constraints.Integer](start, end T) Span[T] {
+	return ClosedStep(start, end, 1)
+}
+
+// ClosedStep is the same as Step, but end is included in the span if the
+// step lands on it.
+func ClosedStep[T constraints.Integer](start, end, step T) Span[T] {
+	return Span[T]{
+		Start: start,
+		End: end,
+		Step: step,
+		Inclusive: true,
+	}
+}
+
+// Checked creates a span in the same manner as Step, but refuses to create
+// spans that are likely a mistake: spans with a step of zero, spans whose step
+// points away from their end, and spans with more values than an int can
+// count.
+func Checked[T constraints.Integer](start, end, step T) (Span[T], error) {
+	s := Step(start, end, step)
+	var zero T
+	if step == zero {
+		return Span[T]{}, ErrZeroStep
+	}
+	if (step > zero && end < start) || (step < zero && end > start) {
+		return Span[T]{}, ErrDirection
+	}
+	if p, ok := s.prog(); ok && (p.hi-p.lo)/p.step >= uint64(maxInt) {
+		return Span[T]{}, ErrTooLong
+	}
+	return s, nil
+}
+
+const maxInt = int(^uint(0) >> 1)
@@ -1,6 +1,8 @@
 package span
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/jordanorelli/generic/iter"
@@ -60,3 +62,72 @@ func TestStep(t *testing.T) {
 		t.Log(n)
 	}
 }
+
+// collect gathers every value of an iterable into a slice
+func collect[T any](a iter.Able[T]) []T {
+	var out []T
+	for v, it := iter.Start(a); it.Next(&v); {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestDescending(t *testing.T) {
+	got := fmt.Sprint(collect[int](Step(10, 0, -1)))
+	if got != "[10 9 8 7 6 5 4 3 2 1]" {
+		t.Errorf("unexpected descending span: %s", got)
+	}
+
+	got = fmt.Sprint(collect[int](Step(10, 0, -3)))
+	if got != "[10 7 4 1]" {
+		t.Errorf("unexpected descending span: %s", got)
+	}
+
+	got = fmt.Sprint(collect[int](ClosedStep(10, 1, -3)))
+	if got != "[10 7 4 1]" {
+		t.Errorf("unexpected closed descending span: %s", got)
+	}
+
+	if vals := collect[int](Step(0, 10, -1)); len(vals) != 0 {
+		t.Errorf("a span stepping away from its end should be empty but saw %v", vals)
+	}
+	if vals := collect[int](Step(0, 10, 0)); len(vals) != 0 {
+		t.Errorf("a span with a zero step should be empty but saw %v", vals)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	if n := len(collect[uint8](Closed[uint8](0, 255))); n != 256 {
+		t.Errorf("expected 256 values in a closed uint8 span but saw %d", n)
+	}
+	if n := len(collect[uint8](Step[uint8](250, 255, 3))); n != 2 {
+		t.Errorf("expected 2 values stepping near the top of uint8 but saw %d", n)
+	}
+	if n := len(collect[int8](ClosedStep[int8](127, -128, -1))); n != 256 {
+		t.Errorf("expected 256 values in a descending int8 span but saw %d", n)
+	}
+
+	got := fmt.Sprint(collect[int8](ClosedStep[int8](-100, 120, 100)))
+	if got != "[-100 0 100]" {
+		t.Errorf("unexpected int8 span: %s", got)
+	}
+}
+
+func TestChecked(t *testing.T) {
+	if _, err := Checked(0, 10, 0); !errors.Is(err, ErrZeroStep) {
+		t.Errorf("expected zero step error but saw %v", err)
+	}
+	if _, err := Checked(0, 10, -1); !errors.Is(err, ErrDirection) {
+		t.Errorf("expected direction error but saw %v", err)
+	}
+	if _, err := Checked[uint64](0, ^uint64(0), 1); !errors.Is(err, ErrTooLong) {
+		t.Errorf("expected too long error but saw %v", err)
+	}
+	s, err := Checked(10, 0, -2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(collect[int](s)); got != "[10 8 6 4 2]" {
+		t.Errorf("unexpected checked span: %s", got)
+	}
+}
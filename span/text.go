@@ -0,0 +1,166 @@
+package span
+
+import (
+	"constraints"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The text form of a span is one of:
+//
+//     a-b       the values from a to b, including b; the same as Closed(a, b)
+//     a..b      the values from a up to but not including b; the same as New(a, b)
+//     a         just the value a; the same as Closed(a, a)
+//
+// Either of the first two may be followed by :step to give a step other than
+// one, e.g., 0..100:5 or 10-0:-2. Negative numbers are written as usual, so
+// -10--1 is the values from -10 to -1. A set is written as a comma-separated
+// list of spans, e.g., 3,5,7-9.
+
+// String formats the span in the text form accepted by Parse
+func (s Span[T]) String() string {
+	sep := ".."
+	if s.Inclusive {
+		sep = "-"
+	}
+	if s.Step == 1 {
+		if s.Inclusive && s.Start == s.End {
+			return fmt.Sprint(s.Start)
+		}
+		return fmt.Sprintf("%v%s%v", s.Start, sep, s.End)
+	}
+	return fmt.Sprintf("%v%s%v:%v", s.Start, sep, s.End, s.Step)
+}
+
+// MarshalText encodes the span in the text form accepted by Parse
+func (s Span[T]) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText decodes a span written in the text form accepted by Parse
+func (s *Span[T]) UnmarshalText(b []byte) error {
+	parsed, err := Parse[T](string(b))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// Parse reads a span written like 1-10, 0..100:5 or 7. See String for the
+// details of the format.
+func Parse[T constraints.Integer](s string) (Span[T], error) {
+	sp, err := parseSpan[T](strings.TrimSpace(s))
+	if err != nil {
+		return Span[T]{}, fmt.Errorf("invalid span %q: %w", s, err)
+	}
+	return sp, nil
+}
+
+func parseSpan[T constraints.Integer](s string) (Span[T], error) {
+	start, rest, err := parseNum[T](s)
+	if err != nil {
+		return Span[T]{}, err
+	}
+	if rest == "" {
+		return Closed(start, start), nil
+	}
+
+	var sp Span[T]
+	switch {
+	case strings.HasPrefix(rest, ".."):
+		sp, rest = Span[T]{Start: start, Step: 1}, rest[2:]
+	case strings.HasPrefix(rest, "-"):
+		sp, rest = Span[T]{Start: start, Step: 1, Inclusive: true}, rest[1:]
+	default:
+		return Span[T]{}, fmt.Errorf("expected - or .. after %v", start)
+	}
+
+	sp.End, rest, err = parseNum[T](rest)
+	if err != nil {
+		return Span[T]{}, err
+	}
+	if rest == "" {
+		return sp, nil
+	}
+
+	if rest[0] != ':' {
+		return Span[T]{}, fmt.Errorf("unexpected %q after end", rest)
+	}
+	sp.Step, rest, err = parseNum[T](rest[1:])
+	if err != nil {
+		return Span[T]{}, err
+	}
+	if rest != "" {
+		return Span[T]{}, fmt.Errorf("unexpected %q after step", rest)
+	}
+	return sp, nil
+}
+
+// parseNum parses the number at the start of s, returning whatever follows it
+func parseNum[T constraints.Integer](s string) (T, string, error) {
+	n := 0
+	if n < len(s) && (s[n] == '-' || s[n] == '+') {
+		n++
+	}
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+
+	var v T
+	bits := reflect.TypeOf(v).Bits()
+	if signed[T]() {
+		i, err := strconv.ParseInt(s[:n], 10, bits)
+		if err != nil {
+			return v, s, err
+		}
+		return T(i), s[n:], nil
+	}
+	u, err := strconv.ParseUint(s[:n], 10, bits)
+	if err != nil {
+		return v, s, err
+	}
+	return T(u), s[n:], nil
+}
+
+// String formats the set as a comma-separated list of its spans, in the text
+// form accepted by ParseSet
+func (s *Set[T]) String() string {
+	parts := make([]string, len(s.ivs))
+	for i, sp := range s.Spans() {
+		parts[i] = sp.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+// MarshalText encodes the set in the text form accepted by ParseSet
+func (s *Set[T]) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText decodes a set written in the text form accepted by ParseSet,
+// replacing the contents of the set
+func (s *Set[T]) UnmarshalText(b []byte) error {
+	parsed, err := ParseSet[T](string(b))
+	if err != nil {
+		return err
+	}
+	*s = *parsed
+	return nil
+}
+
+// ParseSet reads a set written as a comma-separated list of spans, such as
+// 3,5,7-9. The spans may overlap and may be in any order. An empty string is
+// an empty set.
+func ParseSet[T constraints.Integer](s string) (*Set[T], error) {
+	var set Set[T]
+	if strings.TrimSpace(s) == "" {
+		return &set, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		sp, err := Parse[T](part)
+		if err != nil {
+			return nil, err
+		}
+		set.Add(sp)
+	}
+	return &set, nil
+}
@@ -0,0 +1,87 @@
+package span
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in   string
+		span Span[int]
+	}{
+		{"1-10", Closed(1, 10)},
+		{"0..100:5", Step(0, 100, 5)},
+		{"7", Closed(7, 7)},
+		{" 10-0:-2 ", ClosedStep(10, 0, -2)},
+		{"-10--1", Closed(-10, -1)},
+		{"-5..5", New(-5, 5)},
+	}
+	for _, c := range cases {
+		sp, err := Parse[int](c.in)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", c.in, err)
+			continue
+		}
+		if sp != c.span {
+			t.Errorf("parsing %q: expected %#v but saw %#v", c.in, c.span, sp)
+		}
+
+		again, err := Parse[int](sp.String())
+		if err != nil || again != sp {
+			t.Errorf("%#v did not round trip through %q", sp, sp.String())
+		}
+	}
+
+	for _, bad := range []string{"", "a-b", "1-", "1..2:", "1-2:3:4", "1~2", "1-300"} {
+		if _, err := Parse[uint8](bad); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	s, err := ParseSet[int]("3,5,7-9, 4")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := s.String(); got != "3-5,7-9" {
+		t.Errorf("unexpected set: %s", got)
+	}
+	if got := fmt.Sprint(collect[int](s)); got != "[3 4 5 7 8 9]" {
+		t.Errorf("unexpected set values: %s", got)
+	}
+
+	if _, err := ParseSet[int]("1,x"); err == nil {
+		t.Errorf("expected an error parsing a bad set")
+	}
+}
+
+func TestText(t *testing.T) {
+	type config struct {
+		Shards Span[int]
+		Ports  *Set[uint16]
+	}
+
+	in := config{Shards: Step(0, 64, 8), Ports: NewSet(Closed[uint16](8000, 8010), Closed[uint16](9000, 9000))}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	if string(b) != `{"Shards":"0..64:8","Ports":"8000-8010,9000"}` {
+		t.Errorf("unexpected json: %s", b)
+	}
+
+	var out config
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if out.Shards != in.Shards || out.Ports.String() != in.Ports.String() {
+		t.Errorf("config did not round trip: %v", out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Shards":"zero"}`), &out); err == nil {
+		t.Errorf("expected an error unmarshaling a bad span")
+	}
+}
@@ -0,0 +1,118 @@
+package span
+
+import (
+	"time"
+
+	"github.com/jordanorelli/generic/iter"
+)
+
+// Period is the distance between consecutive values of a Time span. The
+// calendar part of a period (years, months and days) follows the calendar:
+// stepping by one day keeps the same wall clock time across a daylight saving
+// time change, even though that day lasts 23 or 25 hours, and stepping by one
+// month from the 31st lands on the last day of shorter months rather than
+// spilling over into the month after. Years and months are applied first,
+// then days, and the Duration part, which is an exact amount of elapsed time,
+// is applied last.
+type Period struct {
+	Years    int
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// Time is a span of points in time from Start towards End, which is not part
+// of the span. The i'th value is always computed from Start by applying the
+// period i times over, rather than from the previous value; this way a
+// monthly span starting on January 31st visits February 28th and then goes
+// back to March 31st, rather than getting stuck on the 28th. A period that
+// moves backwards in time counts down from Start towards End.
+type Time struct {
+	Start time.Time
+	End   time.Time
+	Every Period
+}
+
+// Every creates a span of points in time that are an exact duration apart,
+// e.g., Every(start, end, 15*time.Minute)
+func Every(start, end time.Time, step time.Duration) Time {
+	return Time{Start: start, End: end, Every: Period{Duration: step}}
+}
+
+// Calendar creates a span of points in time that are a number of calendar
+// years, months and days apart, e.g., Calendar(start, end, 0, 1, 0) for
+// monthly values
+func Calendar(start, end time.Time, years, months, days int) Time {
+	return Time{Start: start, End: end, Every: Period{Years: years, Months: months, Days: days}}
+}
+
+// At gets the i'th value of the span, without checking whether it's past the
+// end of the span
+func (s Time) At(i int) time.Time {
+	p := s.Every
+	return addMonths(s.Start, i*(12*p.Years+p.Months)).AddDate(0, 0, i*p.Days).Add(time.Duration(i) * p.Duration)
+}
+
+// addMonths moves t by n calendar months, keeping its day of the month unless
+// the target month is too short for it, in which case the result is the last
+// day of the target month. time.Time.AddDate would instead normalize e.g.
+// February 31st to March 3rd.
+func addMonths(t time.Time, n int) time.Time {
+	if n == 0 {
+		return t
+	}
+	year, month, day := t.Date()
+	months := int(month) - 1 + n
+	year += months / 12
+	months %= 12
+	if months < 0 {
+		months += 12
+		year--
+	}
+
+	// day 0 of the following month is the last day of this one
+	last := time.Date(year, time.Month(months)+2, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		day = last
+	}
+	hour, minute, sec := t.Clock()
+	return time.Date(year, time.Month(months)+1, day, hour, minute, sec, t.Nanosecond(), t.Location())
+}
+
+// before reports whether t hasn't reached the end of the span yet. The
+// direction of the span is decided by where its second value lands.
+func (s Time) before(t time.Time) bool {
+	second := s.At(1)
+	switch {
+	case second.After(s.Start):
+		return t.Before(s.End)
+	case second.Before(s.Start):
+		return t.After(s.End)
+	default:
+		return false
+	}
+}
+
+type timeIter struct {
+	span Time
+	i    int
+	done bool
+}
+
+func (s Time) Iter() iter.Ator[time.Time] { return &timeIter{span: s} }
+
+func (it *timeIter) Next(v *time.Time) bool {
+	if it.done {
+		return false
+	}
+	next := it.span.At(it.i)
+	if !it.span.before(next) {
+		it.done = true
+		return false
+	}
+	*v = next
+	it.i++
+	return true
+}
+
+func (it timeIter) Iter() iter.Ator[time.Time] { return &it }
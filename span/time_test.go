@@ -0,0 +1,69 @@
+package span
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTimeEvery(t *testing.T) {
+	start := time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)
+	vals := collect[time.Time](Every(start, start.Add(time.Hour), 15*time.Minute))
+	if len(vals) != 4 {
+		t.Fatalf("expected 4 values but saw %d", len(vals))
+	}
+	if vals[3] != start.Add(45*time.Minute) {
+		t.Errorf("unexpected last value: %v", vals[3])
+	}
+
+	back := collect[time.Time](Every(start, start.Add(-time.Hour), -30*time.Minute))
+	if len(back) != 2 || back[1] != start.Add(-30*time.Minute) {
+		t.Errorf("unexpected descending times: %v", back)
+	}
+
+	if vals := collect[time.Time](Every(start, start.Add(time.Hour), 0)); len(vals) != 0 {
+		t.Errorf("expected a zero step to be empty but saw %v", vals)
+	}
+}
+
+func TestTimeCalendar(t *testing.T) {
+	start := time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)
+	vals := collect[time.Time](Calendar(start, start.AddDate(1, 0, 0), 0, 1, 0))
+	if len(vals) != 12 {
+		t.Fatalf("expected 12 monthly values but saw %d", len(vals))
+	}
+	for i, v := range vals {
+		if v.Month() != time.Month(i+1) {
+			t.Errorf("expected value %d to be in %v but saw %s", i, time.Month(i+1), v.Format("2006-01-02"))
+		}
+	}
+	if got := vals[1].Format("2006-01-02"); got != "2022-02-28" {
+		t.Errorf("expected the second month to land on 2022-02-28 but saw %s", got)
+	}
+	if got := vals[2].Format("2006-01-02"); got != "2022-03-31" {
+		t.Errorf("expected the third month to land on 2022-03-31 but saw %s", got)
+	}
+
+	leap := time.Date(2020, 2, 29, 12, 0, 0, 0, time.UTC)
+	back := collect[time.Time](Calendar(leap, leap.AddDate(-3, 0, 0), -1, 0, 0))
+	if got := fmt.Sprintf("%d %s", len(back), back[1].Format("2006-01-02 15:04")); got != "3 2019-02-28 12:00" {
+		t.Errorf("unexpected yearly values counting back from a leap day: %v", back)
+	}
+	months := collect[time.Time](Calendar(start, start.AddDate(0, -3, 0), 0, -1, 0))
+	if got := months[len(months)-1].Format("2006-01-02"); len(months) != 3 || got != "2021-11-30" {
+		t.Errorf("unexpected monthly values counting back: %v", months)
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+
+	// daylight saving time started on 2022-03-13 in New York
+	day := time.Date(2022, 3, 12, 9, 0, 0, 0, ny)
+	for _, v := range collect[time.Time](Calendar(day, day.AddDate(0, 0, 3), 0, 0, 1)) {
+		if v.Hour() != 9 {
+			t.Errorf("expected daily steps to stay at 9am across DST but saw %v", v)
+		}
+	}
+}
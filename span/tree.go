@@ -0,0 +1,135 @@
+package span
+
+import (
+	"constraints"
+	"math/rand"
+)
+
+// IntervalTree associates values with spans and answers the question "which
+// values have a span containing x?" in O(log n + m) time for a tree of n
+// spans with m matches. It's an augmented binary search tree: nodes are
+// ordered by the lowest value of their span and each node knows the highest
+// value of any span beneath it, which lets a query skip every subtree that
+// ends before the point in question. The tree is kept balanced as a treap.
+// The zero value is an empty tree ready to use.
+type IntervalTree[T constraints.Integer, V any] struct {
+	root *treeNode[V]
+	size int
+}
+
+type treeNode[V any] struct {
+	p     prog
+	val   V
+	max   uint64
+	prio  uint32
+	left  *treeNode[V]
+	right *treeNode[V]
+}
+
+// fix recomputes the max of a node from its own span and its children
+func (n *treeNode[V]) fix() {
+	n.max = n.p.hi
+	if n.left != nil && n.left.max > n.max {
+		n.max = n.left.max
+	}
+	if n.right != nil && n.right.max > n.max {
+		n.max = n.right.max
+	}
+}
+
+// Insert associates the value v with the span s. The same span may be
+// inserted any number of times with different values. Empty spans are
+// ignored.
+func (t *IntervalTree[T, V]) Insert(s Span[T], v V) {
+	p, ok := s.prog()
+	if !ok {
+		return
+	}
+	t.root = insert(t.root, &treeNode[V]{p: p, val: v, max: p.hi, prio: rand.Uint32()})
+	t.size++
+}
+
+func insert[V any](root, n *treeNode[V]) *treeNode[V] {
+	if root == nil {
+		return n
+	}
+	if n.p.lo < root.p.lo {
+		root.left = insert(root.left, n)
+		if root.left.prio > root.prio {
+			root = rotateRight(root)
+		}
+	} else {
+		root.right = insert(root.right, n)
+		if root.right.prio > root.prio {
+			root = rotateLeft(root)
+		}
+	}
+	root.fix()
+	return root
+}
+
+func rotateRight[V any](n *treeNode[V]) *treeNode[V] {
+	l := n.left
+	n.left, l.right = l.right, n
+	n.fix()
+	l.fix()
+	return l
+}
+
+func rotateLeft[V any](n *treeNode[V]) *treeNode[V] {
+	r := n.right
+	n.right, r.left = r.left, n
+	n.fix()
+	r.fix()
+	return r
+}
+
+// Len is the number of spans in the tree
+func (t *IntervalTree[T, V]) Len() int { return t.size }
+
+// Stab finds the values of every span that contains x, ordered by the lowest
+// value of their spans. Spans with a step other than 1 only match the values
+// they actually visit.
+func (t *IntervalTree[T, V]) Stab(x T) []V {
+	var out []V
+	k := key(x)
+	t.walk(t.root, k, k, func(n *treeNode[V]) {
+		if n.p.contains(k) {
+			out = append(out, n.val)
+		}
+	})
+	return out
+}
+
+// Overlapping finds the values of every span that has at least one value in
+// common with s, ordered by the lowest value of their spans
+func (t *IntervalTree[T, V]) Overlapping(s Span[T]) []V {
+	q, ok := s.prog()
+	if !ok {
+		return nil
+	}
+	var out []V
+	t.walk(t.root, q.lo, q.hi, func(n *treeNode[V]) {
+		if _, ok := intersect(n.p, q); ok {
+			out = append(out, n.val)
+		}
+	})
+	return out
+}
+
+// walk calls fn in order on every node whose lo-hi range overlaps the keys
+// lo-hi
+func (t *IntervalTree[T, V]) walk(n *treeNode[V], lo, hi uint64, fn func(*treeNode[V])) {
+	if n == nil || n.max < lo {
+		return
+	}
+	t.walk(n.left, lo, hi, fn)
+	if n.p.lo > hi {
+		// everything to the right starts even later
+		return
+	}
+	if n.p.hi >= lo {
+		fn(n)
+	}
+	t.walk(n.right, lo, hi, fn)
+}
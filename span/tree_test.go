@@ -0,0 +1,66 @@
+package span
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIntervalTree(t *testing.T) {
+	var tree IntervalTree[int, string]
+	tree.Insert(Closed(8000, 8100), "web")
+	tree.Insert(Closed(8050, 8060), "metrics")
+	tree.Insert(Closed(9000, 9010), "db")
+	tree.Insert(Step(8000, 9000, 100), "round")
+	tree.Insert(New(5, 5), "empty")
+
+	if n := tree.Len(); n != 4 {
+		t.Errorf("expected 4 spans but saw %d", n)
+	}
+
+	cases := []struct {
+		x   int
+		out string
+	}{
+		{8055, "[web metrics]"},
+		{8100, "[web round]"},
+		{8101, "[]"},
+		{8500, "[round]"},
+		{9005, "[db]"},
+		{7999, "[]"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprint(tree.Stab(c.x)); got != c.out {
+			t.Errorf("stab %d: expected %s but saw %s", c.x, c.out, got)
+		}
+	}
+
+	if got := fmt.Sprint(tree.Overlapping(Closed(8095, 9000))); got != "[web round db]" {
+		t.Errorf("unexpected overlapping values: %s", got)
+	}
+}
+
+func TestIntervalTreeSequential(t *testing.T) {
+	// sequential inserts are the worst case for an unbalanced tree
+	var tree IntervalTree[int, int]
+	for i := 0; i < 10000; i++ {
+		tree.Insert(Closed(i*10, i*10+14), i)
+	}
+
+	if got := fmt.Sprint(tree.Stab(50012)); got != "[5000 5001]" {
+		t.Errorf("unexpected stab: %s", got)
+	}
+	if depth := tree.root.depth(); depth > 64 {
+		t.Errorf("tree is badly balanced, depth %d", depth)
+	}
+}
+
+func (n *treeNode[V]) depth() int {
+	if n == nil {
+		return 0
+	}
+	l, r := n.left.depth(), n.right.depth()
+	if l > r {
+		return l + 1
+	}
+	return r + 1
+}
@@ -0,0 +1,22 @@
+// tuple provides small fixed-size groupings of values of differing types
+package tuple
+
+import (
+	"fmt"
+)
+
+// Pair is a pair of values, one of type A and one of type B
+type Pair[A any, B any] struct {
+	Left  A
+	Right B
+}
+
+// Of creates a pair out of two values
+func Of[A any, B any](left A, right B) Pair[A, B] {
+	return Pair[A, B]{Left: left, Right: right}
+}
+
+// Open retrieves both values of the pair at once
+func (p Pair[A, B]) Open() (A, B) { return p.Left, p.Right }
+
+func (p Pair[A, B]) String() string { return fmt.Sprintf("(%v, %v)", p.Left, p.Right) }
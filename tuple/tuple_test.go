@@ -0,0 +1,18 @@
+package tuple
+
+import (
+	"testing"
+)
+
+func TestPair(t *testing.T) {
+	p := Of("alice", 3)
+
+	name, n := p.Open()
+	if name != "alice" || n != 3 {
+		t.Errorf("expected (alice, 3) but saw (%v, %v) instead", name, n)
+	}
+
+	if s := p.String(); s != "(alice, 3)" {
+		t.Errorf("unexpected string value: %s", s)
+	}
+}